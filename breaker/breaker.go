@@ -0,0 +1,213 @@
+// Package breaker 提供 Provider HTTP 调用的熔断器。
+//
+// Provider 故障时，熔断器在失败率超过阈值后打开，之后的请求直接返回
+// kycerrors.ErrProviderUnavailable，而不是各自等到超时；冷却时间结束后进入半开状态，
+// 放行少量探测请求，探测成功则恢复，失败则重新打开。
+package breaker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+)
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// Outcome 是一次请求对熔断器的结果。
+type Outcome int
+
+const (
+	Success Outcome = iota // Provider 正常响应（包括 4xx）
+	Failure                // 5xx、网络错误或超时
+	Ignore                 // 调用方取消等与 Provider 无关的结果，不计入统计
+)
+
+type Settings struct {
+	// Name 用于状态回调与指标，通常为 Provider 名称。
+	Name string
+	// Window 是关闭状态下统计失败率的时间窗口，默认 30 秒。
+	Window time.Duration
+	// MinRequests 是窗口内触发熔断所需的最少请求数，默认 10。
+	MinRequests int
+	// FailureRatio 是触发熔断的失败率（0-1），默认 0.5。
+	FailureRatio float64
+	// CoolDown 是打开状态持续的时间，之后进入半开状态，默认 30 秒。
+	CoolDown time.Duration
+	// HalfOpenRequests 是半开状态放行的探测请求数，全部成功后关闭，默认 1。
+	HalfOpenRequests int
+	// OnStateChange 在状态变化时同步调用，不应阻塞。
+	OnStateChange func(name string, from, to State)
+}
+
+type Breaker struct {
+	settings Settings
+	now      func() time.Time
+
+	mu          sync.Mutex
+	state       State
+	generation  uint64 // 每次状态变化加一，用于丢弃上一状态发出的请求结果
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	inFlight    int // 半开状态下已放行的探测请求
+	successes   int // 半开状态下成功的探测请求
+}
+
+func New(s Settings) *Breaker {
+	if s.Window <= 0 {
+		s.Window = 30 * time.Second
+	}
+	if s.MinRequests <= 0 {
+		s.MinRequests = 10
+	}
+	if s.FailureRatio <= 0 || s.FailureRatio > 1 {
+		s.FailureRatio = 0.5
+	}
+	if s.CoolDown <= 0 {
+		s.CoolDown = 30 * time.Second
+	}
+	if s.HalfOpenRequests <= 0 {
+		s.HalfOpenRequests = 1
+	}
+	b := &Breaker{settings: s, now: time.Now}
+	b.windowStart = b.now()
+	return b
+}
+
+// State 返回当前状态，打开状态在冷却结束后视为半开。
+func (b *Breaker) State() State {
+	if b == nil {
+		return StateClosed
+	}
+	b.mu.Lock()
+	state, change := b.refresh(b.now())
+	b.mu.Unlock()
+	b.notify(change)
+	return state
+}
+
+// Allow 判断请求能否发出。允许时返回的 done 必须以请求结果调用一次；
+// 熔断打开（或半开状态的探测名额已满）时返回包装了 kycerrors.ErrProviderUnavailable 的错误。
+func (b *Breaker) Allow() (done func(Outcome), err error) {
+	if b == nil {
+		return func(Outcome) {}, nil
+	}
+
+	b.mu.Lock()
+	state, change := b.refresh(b.now())
+	switch {
+	case state == StateOpen,
+		state == StateHalfOpen && b.inFlight >= b.settings.HalfOpenRequests:
+		b.mu.Unlock()
+		b.notify(change)
+		return nil, fmt.Errorf("%w: %s circuit %s", kycerrors.ErrProviderUnavailable, b.settings.Name, state)
+	case state == StateHalfOpen:
+		b.inFlight++
+	}
+	gen := b.generation
+	b.mu.Unlock()
+	b.notify(change)
+
+	var once sync.Once
+	return func(o Outcome) {
+		once.Do(func() { b.record(gen, o) })
+	}, nil
+}
+
+func (b *Breaker) record(gen uint64, o Outcome) {
+	b.mu.Lock()
+	now := b.now()
+	_, change := b.refresh(now)
+	if gen != b.generation {
+		b.mu.Unlock()
+		b.notify(change)
+		return
+	}
+
+	switch b.state {
+	case StateClosed:
+		if o == Ignore {
+			break
+		}
+		b.requests++
+		if o == Failure {
+			b.failures++
+		}
+		if b.requests >= b.settings.MinRequests && float64(b.failures) >= b.settings.FailureRatio*float64(b.requests) {
+			change = b.setState(now, StateOpen)
+		}
+	case StateHalfOpen:
+		b.inFlight--
+		switch o {
+		case Failure:
+			change = b.setState(now, StateOpen)
+		case Success:
+			b.successes++
+			if b.successes >= b.settings.HalfOpenRequests {
+				change = b.setState(now, StateClosed)
+			}
+		}
+	}
+	b.mu.Unlock()
+	b.notify(change)
+}
+
+type transition struct {
+	from, to State
+}
+
+// refresh 处理随时间发生的变化：关闭状态的窗口滚动、打开状态冷却结束。调用方持有锁。
+func (b *Breaker) refresh(now time.Time) (State, *transition) {
+	var change *transition
+	switch b.state {
+	case StateClosed:
+		if now.Sub(b.windowStart) >= b.settings.Window {
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+	case StateOpen:
+		if now.Sub(b.openedAt) >= b.settings.CoolDown {
+			change = b.setState(now, StateHalfOpen)
+		}
+	}
+	return b.state, change
+}
+
+func (b *Breaker) setState(now time.Time, to State) *transition {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.windowStart, b.requests, b.failures = now, 0, 0
+	b.inFlight, b.successes = 0, 0
+	if to == StateOpen {
+		b.openedAt = now
+	}
+	return &transition{from: from, to: to}
+}
+
+func (b *Breaker) notify(t *transition) {
+	if t != nil && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(b.settings.Name, t.from, t.to)
+	}
+}
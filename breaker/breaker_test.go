@@ -0,0 +1,117 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+)
+
+type transitionLog []string
+
+func newTestBreaker(s Settings) (*Breaker, *time.Time, *transitionLog) {
+	var log transitionLog
+	s.OnStateChange = func(name string, from, to State) {
+		log = append(log, from.String()+"->"+to.String())
+	}
+	b := New(s)
+	now := time.Unix(1_700_000_000, 0)
+	b.now = func() time.Time { return now }
+	b.windowStart = now
+	return b, &now, &log
+}
+
+func call(t *testing.T, b *Breaker, o Outcome) {
+	t.Helper()
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	done(o)
+}
+
+func TestBreaker_OpensOnFailureRatio(t *testing.T) {
+	b, now, log := newTestBreaker(Settings{Name: "sumsub", MinRequests: 4, FailureRatio: 0.5, CoolDown: 10 * time.Second})
+
+	call(t, b, Success)
+	call(t, b, Failure)
+	call(t, b, Ignore)
+	call(t, b, Success)
+	if b.State() != StateClosed {
+		t.Fatalf("expected closed below MinRequests")
+	}
+	call(t, b, Failure)
+
+	if b.State() != StateOpen {
+		t.Fatalf("expected open, got %s", b.State())
+	}
+	_, err := b.Allow()
+	if !errors.Is(err, kycerrors.ErrProviderUnavailable) {
+		t.Fatalf("expected ErrProviderUnavailable, got: %v", err)
+	}
+
+	*now = now.Add(10 * time.Second)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("expected half-open after cool-down, got %s", b.State())
+	}
+	if got := (*log)[len(*log)-1]; got != "open->half-open" {
+		t.Fatalf("unexpected transition %s", got)
+	}
+}
+
+func TestBreaker_WindowResetsCounts(t *testing.T) {
+	b, now, _ := newTestBreaker(Settings{MinRequests: 2, FailureRatio: 1, Window: time.Second})
+
+	call(t, b, Failure)
+	*now = now.Add(time.Second)
+	call(t, b, Failure)
+	if b.State() != StateClosed {
+		t.Fatalf("failures from an expired window must not count")
+	}
+}
+
+func TestBreaker_HalfOpenProbes(t *testing.T) {
+	b, now, log := newTestBreaker(Settings{MinRequests: 1, CoolDown: time.Second, HalfOpenRequests: 2})
+
+	call(t, b, Failure)
+	*now = now.Add(time.Second)
+
+	// 探测失败重新打开。
+	call(t, b, Failure)
+	if b.State() != StateOpen {
+		t.Fatalf("expected re-open after failed probe, got %s", b.State())
+	}
+
+	*now = now.Add(time.Second)
+	first, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow probe 1: %v", err)
+	}
+	second, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow probe 2: %v", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, kycerrors.ErrProviderUnavailable) {
+		t.Fatalf("expected probes exhausted, got: %v", err)
+	}
+	first(Success)
+	first(Success) // 重复调用无效
+	if b.State() != StateHalfOpen {
+		t.Fatalf("expected half-open until all probes succeed")
+	}
+	second(Success)
+	if b.State() != StateClosed {
+		t.Fatalf("expected closed, got %s", b.State())
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(*log) != len(want) {
+		t.Fatalf("unexpected transitions %v", *log)
+	}
+	for i := range want {
+		if (*log)[i] != want[i] {
+			t.Fatalf("unexpected transitions %v", *log)
+		}
+	}
+}
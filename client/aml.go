@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+// AMLProvider 是支持 AML 筛查（制裁 / PEP / 负面新闻）的 Provider 需要额外实现的接口。
+type AMLProvider interface {
+	RunAMLCheck(ctx context.Context, applicantID string) error
+	GetAMLResults(ctx context.Context, applicantID string) (*model.AMLResult, error)
+}
+
+// RunAMLCheck 触发一次 AML 筛查。筛查是异步的，结果通过 GetAMLResults 查询或
+// applicantAmlCaseChanged Webhook 获知。
+func (c *Client) RunAMLCheck(ctx context.Context, applicantID string, opts ...CallOption) (err error) {
+	p, err := c.amlProvider()
+	if err != nil {
+		return err
+	}
+	ctx, call := c.begin(ctx, "RunAMLCheck", opts, applicantAttr(applicantID))
+	defer func() { call.end(err) }()
+
+	return p.RunAMLCheck(ctx, applicantID)
+}
+
+func (c *Client) GetAMLResults(ctx context.Context, applicantID string, opts ...CallOption) (_ *model.AMLResult, err error) {
+	p, err := c.amlProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "GetAMLResults", opts, applicantAttr(applicantID))
+	defer func() { call.end(err) }()
+
+	res, err := p.GetAMLResults(ctx, applicantID)
+	if err != nil {
+		return nil, err
+	}
+	call.span.SetAttributes(resultAttr(res.Result))
+	return res, nil
+}
+
+func (c *Client) amlProvider() (AMLProvider, error) {
+	if c == nil || c.provider == nil {
+		return nil, errors.New("nil client")
+	}
+	p, ok := c.provider.(AMLProvider)
+	if !ok {
+		return nil, kycerrors.ErrNotSupported
+	}
+	return p, nil
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func TestClient_RunAMLCheck(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/applicants/a1/recheck/aml" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		if r.ContentLength > 0 {
+			t.Fatalf("expected empty body")
+		}
+		called = true
+		_, _ = w.Write([]byte(`{"ok":1}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if err := cli.RunAMLCheck(context.Background(), "a1"); err != nil {
+		t.Fatalf("RunAMLCheck: %v", err)
+	}
+	if !called {
+		t.Fatalf("expected request")
+	}
+}
+
+func TestClient_GetAMLResults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/checks/latest" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("applicantId") != "a1" || r.URL.Query().Get("type") != "AML" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"checks":[{"id":"chk1","answer":"RED","checkType":"AML","createdAt":"2026-01-02 03:04:05","aml":{"hits":[{"name":"John Doe","listName":"OFAC SDN","category":"sanctions","matchStrength":"strong","entityType":"person","countries":["IRN"]}]}}]}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	res, err := cli.GetAMLResults(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("GetAMLResults: %v", err)
+	}
+	if res.CheckID != "chk1" || res.Result != model.ResultRed || res.CheckedAt.IsZero() {
+		t.Fatalf("result mismatch: %+v", res)
+	}
+	if len(res.Hits) != 1 {
+		t.Fatalf("expected 1 hit, got %d", len(res.Hits))
+	}
+	hit := res.Hits[0]
+	if hit.ListName != "OFAC SDN" || hit.Category != model.AMLCategorySanctions || hit.MatchStrength != model.AMLMatchStrong || hit.EntityType != model.AMLEntityPerson {
+		t.Fatalf("hit mismatch: %+v", hit)
+	}
+}
+
+func TestClient_GetAMLResults_Parsing(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	body = `{"checks":[{"id":"chk1","answer":"RED","createdAt":"2026-01-02T03:04:05Z","aml":{"hits":[{"name":"A","matchStrength":""},{"name":"B","matchStrength":"fuzzy"},{"name":"C","matchStrength":"weak"}]}}]}`
+	res, err := cli.GetAMLResults(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("GetAMLResults: %v", err)
+	}
+	if want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC); !res.CheckedAt.Equal(want) {
+		t.Fatalf("CheckedAt mismatch: %v", res.CheckedAt)
+	}
+	for i, want := range []model.AMLMatchStrength{model.AMLMatchUnknown, model.AMLMatchUnknown, model.AMLMatchWeak} {
+		if got := res.Hits[i].MatchStrength; got != want {
+			t.Fatalf("hit %d: expected %s, got %s", i, want, got)
+		}
+	}
+
+	body = `{"checks":[{"id":"chk1","answer":"RED","createdAt":"yesterday"}]}`
+	if _, err := cli.GetAMLResults(context.Background(), "a1"); err == nil || !strings.Contains(err.Error(), "createdAt") {
+		t.Fatalf("expected createdAt parse error, got: %v", err)
+	}
+}
+
+func TestClient_VerifyAndParseWebhook_AMLCaseChanged(t *testing.T) {
+	raw := []byte(`{"type":"applicantAmlCaseChanged","applicantId":"a1","externalUserId":"u1","reviewStatus":"onHold","reviewResult":{"reviewAnswer":"RED","rejectLabels":["SANCTIONS"]}}`)
+	secret := "secret"
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(raw)
+
+	cli, err := NewClient(&config.Config{BaseURL: "https://example.com", AppToken: "app", SecretKey: "app-secret", WebhookSecret: secret})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	h := http.Header{}
+	h.Set("X-Payload-Digest", hex.EncodeToString(mac.Sum(nil)))
+	payload, err := cli.VerifyAndParseWebhook(h, raw)
+	if err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if payload.EventType() != model.EventApplicantAMLCaseChanged || payload.ReviewResult != model.ResultRed {
+		t.Fatalf("payload mismatch: %+v", payload)
+	}
+	if len(payload.RejectLabels) != 1 || payload.RejectLabels[0] != "SANCTIONS" {
+		t.Fatalf("rejectLabels mismatch: %v", payload.RejectLabels)
+	}
+}
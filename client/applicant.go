@@ -7,16 +7,34 @@ import (
 	"github.com/dq/kyc-sdk/model"
 )
 
-func (c *Client) CreateApplicant(ctx context.Context, userID string) (*model.ApplicantInfo, error) {
+func (c *Client) CreateApplicant(ctx context.Context, userID string, opts ...CallOption) (_ *model.ApplicantInfo, err error) {
 	if c == nil || c.provider == nil {
 		return nil, errors.New("nil client")
 	}
-	return c.provider.CreateApplicant(ctx, userID)
+	ctx, call := c.begin(ctx, "CreateApplicant", opts)
+	defer func() { call.end(err) }()
+
+	info, err := c.provider.CreateApplicant(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	c.stampApplicant(info)
+	call.span.SetAttributes(applicantAttr(info.ApplicantID), resultAttr(info.Result))
+	return info, nil
 }
 
-func (c *Client) GetApplicant(ctx context.Context, applicantID string) (*model.ApplicantInfo, error) {
+func (c *Client) GetApplicant(ctx context.Context, applicantID string, opts ...CallOption) (_ *model.ApplicantInfo, err error) {
 	if c == nil || c.provider == nil {
 		return nil, errors.New("nil client")
 	}
-	return c.provider.GetApplicant(ctx, applicantID)
+	ctx, call := c.begin(ctx, "GetApplicant", opts, applicantAttr(applicantID))
+	defer func() { call.end(err) }()
+
+	info, err := c.provider.GetApplicant(ctx, applicantID)
+	if err != nil {
+		return nil, err
+	}
+	c.stampApplicant(info)
+	call.span.SetAttributes(resultAttr(info.Result))
+	return info, nil
 }
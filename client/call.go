@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dq/kyc-sdk/internal/httpclient"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/metrics"
+)
+
+// opCall 跟踪一次 Client 方法调用：span、耗时指标，以及向 HTTP 层传递操作名。
+type opCall struct {
+	c      *Client
+	op     string
+	start  time.Time
+	span   trace.Span
+	cancel context.CancelFunc
+}
+
+func (c *Client) begin(ctx context.Context, op string, opts []CallOption, attrs ...attribute.KeyValue) (context.Context, *opCall) {
+	co := buildCallOptions(opts)
+	attrs = append(attrs,
+		attribute.String("kyc.provider", c.name),
+		attribute.String("kyc.operation", op),
+	)
+	if c.tenant != "" {
+		attrs = append(attrs, attribute.String("kyc.tenant", c.tenant))
+	}
+	if co.requestID != "" {
+		attrs = append(attrs, attribute.String("kyc.request_id", co.requestID))
+	}
+	ctx, span := c.tracer.Start(ctx, "kyc."+op, trace.WithAttributes(attrs...))
+	call := &opCall{c: c, op: op, start: time.Now(), span: span}
+	// WithTimeout 限制整个调用（包括 Router 故障转移、token 刷新等多次 HTTP 请求）的总耗时。
+	if co.timeout > 0 {
+		ctx, call.cancel = context.WithTimeout(ctx, co.timeout)
+	}
+	ctx = httpclient.WithOperation(co.apply(ctx), op)
+	return ctx, call
+}
+
+func (k *opCall) end(err error) {
+	if k.cancel != nil {
+		k.cancel()
+	}
+	k.c.metrics.ObserveRequest(k.c.name, k.op, statusClass(err), time.Since(k.start))
+	endSpan(k.span, err)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// statusClass 把调用结果归类为 2xx / 4xx / 5xx / error（非 HTTP 错误）。
+func statusClass(err error) string {
+	if err == nil {
+		return "2xx"
+	}
+	var httpErr *kycerrors.HTTPError
+	if errors.As(err, &httpErr) {
+		return metrics.StatusClass(httpErr.StatusCode)
+	}
+	return "error"
+}
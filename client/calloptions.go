@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/dq/kyc-sdk/internal/httpclient"
+)
+
+// CallOption 定制单次 Client 方法调用。
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout        time.Duration
+	requestID      string
+	idempotencyKey string
+	headers        http.Header
+}
+
+// WithTimeout 设置本次调用的总超时：调用内的所有 HTTP 请求（例如故障转移、token 刷新）共用这一个期限，
+// 单个请求的超时也不再受 Config.TimeoutSec 限制。
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// WithRequestID 以 X-Request-Id 发送 id，并记录在日志、span 与返回的 HTTPError 中，便于与 Provider 侧关联。
+func WithRequestID(id string) CallOption {
+	return func(o *callOptions) {
+		o.requestID = id
+	}
+}
+
+// WithIdempotencyKey 以 Idempotency-Key 发送 key，供支持幂等的 Provider 去重重复提交。
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// WithHeaders 为本次调用追加请求 header。Provider 自身设置的 header（鉴权、签名等）不会被覆盖。
+func WithHeaders(h http.Header) CallOption {
+	return func(o *callOptions) {
+		if o.headers == nil {
+			o.headers = make(http.Header, len(h))
+		}
+		for k, vs := range h {
+			for _, v := range vs {
+				o.headers.Add(k, v)
+			}
+		}
+	}
+}
+
+// apply 把调用选项写入 ctx，由 httpclient 在发出请求时读取。
+func (o *callOptions) apply(ctx context.Context) context.Context {
+	if o.timeout > 0 {
+		ctx = httpclient.WithTimeout(ctx, o.timeout)
+	}
+	if o.requestID != "" {
+		ctx = httpclient.WithRequestID(ctx, o.requestID)
+	}
+
+	h := o.headers
+	if o.idempotencyKey != "" {
+		if h == nil {
+			h = make(http.Header, 1)
+		}
+		h.Set("Idempotency-Key", o.idempotencyKey)
+	}
+	if len(h) > 0 {
+		ctx = httpclient.WithRequestHeaders(ctx, h)
+	}
+	return ctx
+}
+
+func buildCallOptions(opts []CallOption) *callOptions {
+	o := &callOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
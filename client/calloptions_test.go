@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/kyctest/fakesumsub"
+)
+
+func TestCallOptions_HeadersAndRequestID(t *testing.T) {
+	srv := fakesumsub.New(fakesumsub.Options{})
+	defer srv.Close()
+
+	var sent http.Header
+	cfg := srv.Config()
+	cfg.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req.Header.Clone()
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	cli, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = cli.CreateApplicant(context.Background(), "user-1",
+		WithRequestID("req-1"),
+		WithIdempotencyKey("idem-1"),
+		WithHeaders(http.Header{"X-Tenant": {"eu"}, "X-App-Token": {"spoofed"}}),
+	)
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	for k, want := range map[string]string{
+		"X-Request-Id":    "req-1",
+		"Idempotency-Key": "idem-1",
+		"X-Tenant":        "eu",
+		"X-App-Token":     fakesumsub.DefaultAppToken,
+	} {
+		if got := sent.Get(k); got != want {
+			t.Fatalf("header %s = %q, want %q", k, got, want)
+		}
+	}
+
+	_, err = cli.GetApplicant(context.Background(), "missing", WithRequestID("req-2"))
+	var httpErr *kycerrors.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.RequestID != "req-2" {
+		t.Fatalf("expected HTTPError with request id, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "request_id=req-2") {
+		t.Fatalf("error message missing request id: %v", err)
+	}
+	if sent.Get("Idempotency-Key") != "" {
+		t.Fatalf("call options must not leak into later calls")
+	}
+}
+
+func TestCallOptions_Timeout(t *testing.T) {
+	srv := fakesumsub.New(fakesumsub.Options{})
+	defer srv.Close()
+
+	cli, err := NewClient(srv.Config())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	srv.InjectFault(fakesumsub.Fault{Latency: 300 * time.Millisecond, Times: 1})
+	start := time.Now()
+	_, err = cli.CreateApplicant(context.Background(), "user-1", WithTimeout(50*time.Millisecond), WithRequestID("req-3"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "request_id=req-3") {
+		t.Fatalf("error message missing request id: %v", err)
+	}
+	if time.Since(start) > 250*time.Millisecond {
+		t.Fatalf("per-call timeout not applied")
+	}
+}
+
+// 主 Provider 慢速返回 503 后故障转移到同样慢的备用 Provider：每个请求都在期限内，但整个调用超出 WithTimeout。
+func TestCallOptions_TimeoutCoversWholeCall(t *testing.T) {
+	primary := fakesumsub.New(fakesumsub.Options{})
+	defer primary.Close()
+	backup := fakesumsub.New(fakesumsub.Options{})
+	defer backup.Close()
+	primary.InjectFault(fakesumsub.Fault{Status: http.StatusServiceUnavailable, Latency: 80 * time.Millisecond})
+	backup.InjectFault(fakesumsub.Fault{Latency: 80 * time.Millisecond})
+
+	providers := map[string]Provider{}
+	for name, srv := range map[string]*fakesumsub.Server{"primary": primary, "backup": backup} {
+		cli, err := NewClient(srv.Config())
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		providers[name] = cli.AsProvider()
+	}
+	router, err := NewRouter(RouterConfig{Providers: providers, Default: "primary", Fallbacks: []string{"backup"}})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+	cli, err := New(router)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	_, err = cli.GenerateLink(context.Background(), GenerateLinkRequest{UserID: "user-1", LevelName: "basic-kyc-level"}, WithTimeout(120*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded for the whole call, got: %v", err)
+	}
+}
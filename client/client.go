@@ -3,15 +3,27 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/dq/kyc-sdk/config"
-	"github.com/dq/kyc-sdk/internal/sumsub"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/metrics"
 	"github.com/dq/kyc-sdk/model"
 )
 
 type Client struct {
 	provider Provider
+	name     string
+	logger   *slog.Logger
+	tracer   trace.Tracer
+	metrics  metrics.Recorder
+	recent   *recentWebhooks // 仅在开启指标时用于识别重复回调
+	tenant   string          // MultiTenant 中的租户 key
+	env      config.Environment
 }
 
 type Provider interface {
@@ -21,17 +33,169 @@ type Provider interface {
 	VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error)
 }
 
-func New(provider Provider) (*Client, error) {
+// WebhookContextProvider 是 Provider 的可选接口：验签需要读取凭据（例如 Config.Secrets）的 Provider 实现它，
+// Client.VerifyAndParseWebhookContext 的 ctx 会一并传入。
+type WebhookContextProvider interface {
+	VerifyAndParseWebhookContext(ctx context.Context, headers http.Header, rawBody []byte) (*model.WebhookPayload, error)
+}
+
+// verifyWebhook 在 Provider 支持时把 ctx 传给验签。
+func verifyWebhook(ctx context.Context, p Provider, headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	if wp, ok := p.(WebhookContextProvider); ok {
+		return wp.VerifyAndParseWebhookContext(ctx, headers, rawBody)
+	}
+	return p.VerifyAndParseWebhook(headers, rawBody)
+}
+
+func New(provider Provider, opts ...Option) (*Client, error) {
 	if provider == nil {
 		return nil, errors.New("missing provider")
 	}
-	return &Client{provider: provider}, nil
+	o := buildOptions(opts)
+	c := &Client{
+		provider: provider,
+		logger:   o.clientLogger(),
+		tracer:   o.clientTracer(),
+		metrics:  o.clientMetrics(),
+	}
+	if o.metrics != nil {
+		c.recent = newRecentWebhooks(recentWebhookSize)
+	}
+	if e, ok := provider.(interface{ Environment() config.Environment }); ok {
+		c.env = e.Environment()
+	}
+	return c, nil
 }
 
-func NewClient(cfg *config.Config) (*Client, error) {
-	p, err := sumsub.New(cfg)
+// NewClient 按 cfg.Provider 从注册表中选择 Provider 创建 Client，cfg.Provider 为空时使用 Sumsub。
+// 返回的 ApplicantInfo / CompanyInfo 中的 Provider 字段统一填充为注册名。
+func NewClient(cfg *config.Config, opts ...Option) (*Client, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: nil", kycerrors.ErrInvalidConfig)
+	}
+
+	name := cfg.Provider
+	if name == "" {
+		name = DefaultProvider
+	}
+	factory, err := lookup(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", kycerrors.ErrInvalidConfig, err)
+	}
+
+	o := buildOptions(opts)
+	p, err := factory(o.providerConfig(name, cfg))
 	if err != nil {
 		return nil, err
 	}
-	return New(p)
+	c, err := New(p, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.name = name
+	if env := cfg.Env(); env != "" {
+		c.env = env
+	}
+	return c, nil
+}
+
+// NewOnfidoClient 使用 cfg.Onfido 创建以 Onfido 为 Provider 的 Client。
+func NewOnfidoClient(cfg *config.Config, opts ...Option) (*Client, error) {
+	return newNamedClient("onfido", cfg, opts...)
+}
+
+// NewVeriffClient 使用 cfg.Veriff 创建以 Veriff 为 Provider 的 Client。
+func NewVeriffClient(cfg *config.Config, opts ...Option) (*Client, error) {
+	return newNamedClient("veriff", cfg, opts...)
+}
+
+// NewJumioClient 使用 cfg.Jumio 创建以 Jumio 为 Provider 的 Client。
+func NewJumioClient(cfg *config.Config, opts ...Option) (*Client, error) {
+	return newNamedClient("jumio", cfg, opts...)
+}
+
+// NewPersonaClient 使用 cfg.Persona 创建以 Persona 为 Provider 的 Client。
+func NewPersonaClient(cfg *config.Config, opts ...Option) (*Client, error) {
+	return newNamedClient("persona", cfg, opts...)
+}
+
+func newNamedClient(name string, cfg *config.Config, opts ...Option) (*Client, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: nil", kycerrors.ErrInvalidConfig)
+	}
+	c := *cfg
+	c.Provider = name
+	return NewClient(&c, opts...)
+}
+
+// Environment 返回 Client 的环境（sandbox / production），无法确定时为空。
+// 来自 Config.Env，或通过 New 注入的 Provider 实现的 Environment() 方法。
+func (c *Client) Environment() config.Environment {
+	return c.env
+}
+
+// requireSandbox 拒绝在非 sandbox 环境（包括无法确定环境时）调用仅限 sandbox 的操作。
+func (c *Client) requireSandbox(op string) error {
+	if c.env != config.EnvSandbox {
+		env := string(c.env)
+		if env == "" {
+			env = "unknown"
+		}
+		return fmt.Errorf("%w: %s (environment: %s)", kycerrors.ErrSandboxOnly, op, env)
+	}
+	return nil
+}
+
+// stampApplicant / stampCompany 用注册名覆盖 Provider 字段；
+// 通过 New 直接注入的 Provider 没有注册名，保持原值。
+func (c *Client) stampApplicant(info *model.ApplicantInfo) {
+	if info == nil {
+		return
+	}
+	if c.name != "" {
+		info.Provider = c.name
+	}
+	if c.env != "" {
+		info.Environment = string(c.env)
+	}
+}
+
+func (c *Client) stampCompany(info *model.CompanyInfo) {
+	if c.name != "" && info != nil {
+		info.Provider = c.name
+	}
+}
+
+// AsProvider 把 Client 适配为 Provider（例如作为 Router 的后端），调用仍经过日志、追踪与指标。
+// 外层 Client 的 CallOption 通过 ctx 传递，同样对内层请求生效。
+func (c *Client) AsProvider() Provider {
+	return clientProvider{c}
+}
+
+type clientProvider struct {
+	c *Client
+}
+
+func (p clientProvider) CreateApplicant(ctx context.Context, userID string) (*model.ApplicantInfo, error) {
+	return p.c.CreateApplicant(ctx, userID)
+}
+
+func (p clientProvider) GetApplicant(ctx context.Context, applicantID string) (*model.ApplicantInfo, error) {
+	return p.c.GetApplicant(ctx, applicantID)
+}
+
+func (p clientProvider) GenerateLink(ctx context.Context, req model.GenerateLinkRequest) (string, error) {
+	return p.c.GenerateLink(ctx, req)
+}
+
+func (p clientProvider) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	return p.c.VerifyAndParseWebhook(headers, rawBody)
+}
+
+func (p clientProvider) VerifyAndParseWebhookContext(ctx context.Context, headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	return p.c.VerifyAndParseWebhookContext(ctx, headers, rawBody)
+}
+
+func (p clientProvider) WebhookSignatureHeader() string {
+	return p.c.WebhookSignatureHeader()
 }
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+type CreateCompanyRequest = model.CreateCompanyRequest
+
+type AddBeneficiaryRequest = model.AddBeneficiaryRequest
+
+// CompanyProvider 是支持企业（KYB）认证的 Provider 需要额外实现的接口。
+type CompanyProvider interface {
+	CreateCompanyApplicant(ctx context.Context, req model.CreateCompanyRequest) (*model.CompanyInfo, error)
+	GetCompany(ctx context.Context, applicantID string) (*model.CompanyInfo, error)
+	AddBeneficiary(ctx context.Context, companyApplicantID string, req model.AddBeneficiaryRequest) (*model.Beneficiary, error)
+	ListBeneficiaries(ctx context.Context, companyApplicantID string) ([]model.Beneficiary, error)
+}
+
+func (c *Client) CreateCompanyApplicant(ctx context.Context, req CreateCompanyRequest, opts ...CallOption) (_ *model.CompanyInfo, err error) {
+	p, err := c.companyProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "CreateCompanyApplicant", opts, levelAttr(req.LevelName))
+	defer func() { call.end(err) }()
+
+	info, err := p.CreateCompanyApplicant(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	c.stampCompany(info)
+	call.span.SetAttributes(applicantAttr(info.ApplicantID), resultAttr(info.Result))
+	return info, nil
+}
+
+func (c *Client) GetCompany(ctx context.Context, applicantID string, opts ...CallOption) (_ *model.CompanyInfo, err error) {
+	p, err := c.companyProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "GetCompany", opts, applicantAttr(applicantID))
+	defer func() { call.end(err) }()
+
+	info, err := p.GetCompany(ctx, applicantID)
+	if err != nil {
+		return nil, err
+	}
+	c.stampCompany(info)
+	call.span.SetAttributes(resultAttr(info.Result))
+	return info, nil
+}
+
+func (c *Client) AddBeneficiary(ctx context.Context, companyApplicantID string, req AddBeneficiaryRequest, opts ...CallOption) (_ *model.Beneficiary, err error) {
+	p, err := c.companyProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "AddBeneficiary", opts, applicantAttr(companyApplicantID))
+	defer func() { call.end(err) }()
+
+	return p.AddBeneficiary(ctx, companyApplicantID, req)
+}
+
+func (c *Client) ListBeneficiaries(ctx context.Context, companyApplicantID string, opts ...CallOption) (_ []model.Beneficiary, err error) {
+	p, err := c.companyProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "ListBeneficiaries", opts, applicantAttr(companyApplicantID))
+	defer func() { call.end(err) }()
+
+	return p.ListBeneficiaries(ctx, companyApplicantID)
+}
+
+func (c *Client) companyProvider() (CompanyProvider, error) {
+	if c == nil || c.provider == nil {
+		return nil, errors.New("nil client")
+	}
+	p, ok := c.provider.(CompanyProvider)
+	if !ok {
+		return nil, kycerrors.ErrNotSupported
+	}
+	return p, nil
+}
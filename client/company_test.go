@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func TestClient_CreateCompanyApplicant(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/applicants" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("levelName") != "kyb-level" {
+			t.Fatalf("levelName mismatch: %s", r.URL.Query().Get("levelName"))
+		}
+
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got["type"] != "company" {
+			t.Fatalf("type mismatch: %v", got["type"])
+		}
+		info, _ := got["info"].(map[string]any)
+		ci, ok := info["companyInfo"].(map[string]any)
+		if !ok {
+			t.Fatalf("expected companyInfo")
+		}
+		if ci["registrationNumber"] != "R-1" || ci["country"] != "SGP" {
+			t.Fatalf("companyInfo mismatch: %v", ci)
+		}
+
+		_, _ = w.Write([]byte(`{"id":"c1","externalUserId":"biz-1","info":{"companyInfo":{"companyName":"ACME","registrationNumber":"R-1","country":"SGP"}},"review":{"reviewStatus":"init"}}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	info, err := cli.CreateCompanyApplicant(context.Background(), CreateCompanyRequest{
+		UserID:             "biz-1",
+		LevelName:          "kyb-level",
+		CompanyName:        "ACME",
+		RegistrationNumber: "R-1",
+		Country:            "SGP",
+	})
+	if err != nil {
+		t.Fatalf("CreateCompanyApplicant: %v", err)
+	}
+	if info.ApplicantID != "c1" || info.CompanyName != "ACME" || info.UserID != "biz-1" {
+		t.Fatalf("company mismatch: %+v", info)
+	}
+}
+
+func TestClient_GetCompany_AggregatesBeneficiaries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/resources/applicants/c1":
+			_, _ = w.Write([]byte(`{"id":"c1","externalUserId":"biz-1","info":{"companyInfo":{"companyName":"ACME","beneficiaries":[{"id":"b1","applicantId":"p1","types":["ubo"],"shareSize":60},{"id":"b2","applicantId":"p2","types":["director"]}]}},"review":{"reviewStatus":"completed","reviewResult":{"reviewAnswer":"GREEN"}}}`))
+		case "/resources/applicants/p1":
+			_, _ = w.Write([]byte(`{"id":"p1","review":{"reviewStatus":"completed","reviewResult":{"reviewAnswer":"GREEN"}}}`))
+		case "/resources/applicants/p2":
+			_, _ = w.Write([]byte(`{"id":"p2","review":{"reviewStatus":"completed","reviewResult":{"reviewAnswer":"RED"}}}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	info, err := cli.GetCompany(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("GetCompany: %v", err)
+	}
+	if len(info.Beneficiaries) != 2 {
+		t.Fatalf("expected 2 beneficiaries, got %d", len(info.Beneficiaries))
+	}
+	if info.Beneficiaries[0].Types[0] != model.BeneficiaryUBO || info.Beneficiaries[0].ShareSize != 60 {
+		t.Fatalf("beneficiary mismatch: %+v", info.Beneficiaries[0])
+	}
+	if info.Status != model.StatusReviewed || info.Result != model.ResultRed {
+		t.Fatalf("aggregate mismatch: %s/%s", info.Status, info.Result)
+	}
+}
+
+func TestClient_GetCompany_UnlinkedBeneficiary(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/resources/applicants/c1":
+			_, _ = w.Write([]byte(`{"id":"c1","info":{"companyInfo":{"companyName":"ACME","beneficiaries":[{"id":"b1","applicantId":"p1","types":["ubo"]},{"id":"b2","types":["director"]}]}},"review":{"reviewStatus":"completed","reviewResult":{"reviewAnswer":"GREEN"}}}`))
+		case "/resources/applicants/p1":
+			_, _ = w.Write([]byte(`{"id":"p1","review":{"reviewStatus":"completed","reviewResult":{"reviewAnswer":"GREEN"}}}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	info, err := cli.GetCompany(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("GetCompany: %v", err)
+	}
+	if b := info.Beneficiaries[1]; b.Status != model.StatusUnknown || b.Result != model.ResultNone {
+		t.Fatalf("expected unlinked beneficiary to be UNKNOWN, got %+v", b)
+	}
+	if info.Status != model.StatusUnknown || info.Result == model.ResultGreen {
+		t.Fatalf("aggregate mismatch: %s/%s", info.Status, info.Result)
+	}
+}
+
+func TestClient_AddBeneficiary(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/applicants/c1/info/companyInfo/beneficiaries" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got["applicantId"] != "p1" {
+			t.Fatalf("applicantId mismatch: %v", got["applicantId"])
+		}
+
+		_, _ = w.Write([]byte(`{"id":"b1","applicantId":"p1","types":["ubo","director"],"shareSize":25}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	b, err := cli.AddBeneficiary(context.Background(), "c1", AddBeneficiaryRequest{
+		ApplicantID: "p1",
+		Types:       []model.BeneficiaryType{model.BeneficiaryUBO, model.BeneficiaryDirector},
+		ShareSize:   25,
+	})
+	if err != nil {
+		t.Fatalf("AddBeneficiary: %v", err)
+	}
+	if b.ID != "b1" || len(b.Types) != 2 {
+		t.Fatalf("beneficiary mismatch: %+v", b)
+	}
+}
+
+func TestClient_Company_NotSupported(t *testing.T) {
+	cli, err := New(basicProvider{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if _, err := cli.GetCompany(context.Background(), "c1"); !errors.Is(err, kycerrors.ErrNotSupported) {
+		t.Fatalf("expected ErrNotSupported, got: %v", err)
+	}
+}
+
+// basicProvider 只实现 Provider 基础接口，用于测试可选能力的降级行为。
+type basicProvider struct{}
+
+func (basicProvider) CreateApplicant(ctx context.Context, userID string) (*model.ApplicantInfo, error) {
+	return &model.ApplicantInfo{UserID: userID}, nil
+}
+
+func (basicProvider) GetApplicant(ctx context.Context, applicantID string) (*model.ApplicantInfo, error) {
+	return &model.ApplicantInfo{ApplicantID: applicantID}, nil
+}
+
+func (basicProvider) GenerateLink(ctx context.Context, req model.GenerateLinkRequest) (string, error) {
+	return "https://link", nil
+}
+
+func (basicProvider) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	return &model.WebhookPayload{}, nil
+}
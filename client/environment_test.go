@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/kyctest/fakesumsub"
+)
+
+func TestEnvironment_WebhookSandboxModeMismatch(t *testing.T) {
+	cli, err := NewClient(&config.Config{
+		BaseURL:       "https://api.sumsub.com",
+		AppToken:      "prd:token",
+		SecretKey:     "secret",
+		WebhookSecret: "whsec",
+		Environment:   config.EnvProduction,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if cli.Environment() != config.EnvProduction {
+		t.Fatalf("expected production, got %q", cli.Environment())
+	}
+
+	sign := func(body []byte) http.Header {
+		mac := hmac.New(sha256.New, []byte("whsec"))
+		mac.Write(body)
+		return http.Header{"X-Payload-Digest": {hex.EncodeToString(mac.Sum(nil))}}
+	}
+
+	body := []byte(`{"type":"applicantReviewed","applicantId":"a1","sandboxMode":true}`)
+	if _, err := cli.VerifyAndParseWebhook(sign(body), body); !errors.Is(err, kycerrors.ErrEnvironmentMismatch) {
+		t.Fatalf("expected ErrEnvironmentMismatch, got: %v", err)
+	}
+
+	body = []byte(`{"type":"applicantReviewed","applicantId":"a1","sandboxMode":false}`)
+	payload, err := cli.VerifyAndParseWebhook(sign(body), body)
+	if err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if payload.Environment != string(config.EnvProduction) {
+		t.Fatalf("expected production payload, got %q", payload.Environment)
+	}
+}
+
+func TestEnvironment_ApplicantTagged(t *testing.T) {
+	const token = "sbx:fake-app-token"
+	srv := fakesumsub.New(fakesumsub.Options{AppToken: token})
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{
+		BaseURL:       srv.URL,
+		AppToken:      token,
+		SecretKey:     fakesumsub.DefaultSecretKey,
+		WebhookSecret: fakesumsub.DefaultWebhookSecret,
+		Environment:   config.EnvSandbox,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	info, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	if info.Environment != string(config.EnvSandbox) {
+		t.Fatalf("expected sandbox applicant, got %q", info.Environment)
+	}
+}
+
+func TestEnvironment_RequireSandbox(t *testing.T) {
+	cli := &Client{env: config.EnvProduction}
+	if err := cli.requireSandbox("SimulateReview"); !errors.Is(err, kycerrors.ErrSandboxOnly) {
+		t.Fatalf("expected ErrSandboxOnly, got: %v", err)
+	}
+	cli.env = config.EnvSandbox
+	if err := cli.requireSandbox("SimulateReview"); err != nil {
+		t.Fatalf("requireSandbox: %v", err)
+	}
+}
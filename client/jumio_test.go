@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/internal/httpclient"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func newJumioTestServer(t *testing.T, tokenCalls *int32, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			atomic.AddInt32(tokenCalls, 1)
+			_, _ = w.Write([]byte(`{"access_token":"tok","expires_in":3600,"token_type":"Bearer"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			t.Fatalf("unexpected Authorization: %s", r.Header.Get("Authorization"))
+		}
+		handler(w, r)
+	}))
+}
+
+func TestNewJumioClient_InvalidConfig(t *testing.T) {
+	if _, err := NewJumioClient(&config.Config{}); !errors.Is(err, kycerrors.ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got: %v", err)
+	}
+}
+
+func TestJumioClient_GenerateLink(t *testing.T) {
+	var tokenCalls int32
+	srv := newJumioTestServer(t, &tokenCalls, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/accounts" || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got["customerInternalReference"] != "user-1" {
+			t.Fatalf("customerInternalReference mismatch: %v", got["customerInternalReference"])
+		}
+		wf, _ := got["workflowDefinition"].(map[string]any)
+		if wf["key"] != float64(10013) {
+			t.Fatalf("workflow key mismatch: %v", wf)
+		}
+		if got["callbackUrl"] != "https://hook:pw@example.com/jumio" {
+			t.Fatalf("callbackUrl mismatch: %v", got["callbackUrl"])
+		}
+
+		_, _ = w.Write([]byte(`{"account":{"id":"acc-1"},"web":{"href":"https://jumio/link"},"workflowExecution":{"id":"wfe-1","status":"INITIATED"}}`))
+	})
+	defer srv.Close()
+
+	cli, err := NewJumioClient(&config.Config{Jumio: config.JumioConfig{
+		BaseURL:         srv.URL,
+		AuthURL:         srv.URL,
+		RetrievalURL:    srv.URL,
+		ClientID:        "id",
+		ClientSecret:    "secret",
+		WorkflowKey:     10013,
+		CallbackURL:     "https://example.com/jumio",
+		WebhookUsername: "hook",
+		WebhookPassword: "pw",
+	}})
+	if err != nil {
+		t.Fatalf("NewJumioClient: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		url, err := cli.GenerateLink(context.Background(), GenerateLinkRequest{UserID: "user-1"})
+		if err != nil {
+			t.Fatalf("GenerateLink: %v", err)
+		}
+		if url != "https://jumio/link" {
+			t.Fatalf("url mismatch: %s", url)
+		}
+	}
+	if tokenCalls != 1 {
+		t.Fatalf("expected token to be cached, got %d token calls", tokenCalls)
+	}
+}
+
+func TestJumioClient_GetApplicant_RefreshesTokenOn401(t *testing.T) {
+	var tokenCalls, apiCalls int32
+	var attempts []int
+	srv := newJumioTestServer(t, &tokenCalls, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/accounts/acc-1/workflow-executions/wfe-1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if atomic.AddInt32(&apiCalls, 1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"workflow":{"id":"wfe-1","status":"PROCESSED","customerInternalReference":"user-1"},"decision":{"type":"WARNING"}}`))
+	})
+	defer srv.Close()
+
+	cli, err := NewJumioClient(&config.Config{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/oauth2/token" {
+				attempts = append(attempts, httpclient.Attempt(req.Context()))
+			}
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+		Jumio: config.JumioConfig{
+			AuthURL:      srv.URL,
+			RetrievalURL: srv.URL,
+			ClientID:     "id",
+			ClientSecret: "secret",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewJumioClient: %v", err)
+	}
+
+	info, err := cli.GetApplicant(context.Background(), "acc-1:wfe-1")
+	if err != nil {
+		t.Fatalf("GetApplicant: %v", err)
+	}
+	if info.UserID != "user-1" || info.Status != model.StatusReviewed || info.Result != model.ResultYellow || info.Provider != "jumio" {
+		t.Fatalf("applicant mismatch: %+v", info)
+	}
+	if tokenCalls != 2 {
+		t.Fatalf("expected token refresh after 401, got %d token calls", tokenCalls)
+	}
+	if !slices.Equal(attempts, []int{1, 2}) {
+		t.Fatalf("expected the refreshed request to be attempt 2, got %v", attempts)
+	}
+}
+
+func TestJumioClient_VerifyAndParseWebhook(t *testing.T) {
+	cli, err := NewJumioClient(&config.Config{Jumio: config.JumioConfig{
+		ClientID: "id", ClientSecret: "secret", WebhookUsername: "hook", WebhookPassword: "pw",
+	}})
+	if err != nil {
+		t.Fatalf("NewJumioClient: %v", err)
+	}
+
+	raw := []byte(`{"callbackSentAt":"2026-01-02T03:04:05.000Z","userReference":"user-1","workflowExecution":{"id":"wfe-1","status":"PROCESSED"},"account":{"id":"acc-1"}}`)
+	basic := func(user, pass string) http.Header {
+		r := &http.Request{Header: http.Header{}}
+		r.SetBasicAuth(user, pass)
+		return r.Header
+	}
+
+	payload, err := cli.VerifyAndParseWebhook(basic("hook", "pw"), raw)
+	if err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if payload.EventType() != model.EventApplicantReviewed || payload.ApplicantID != "acc-1:wfe-1" || payload.ExternalUserID != "user-1" {
+		t.Fatalf("payload mismatch: %+v", payload)
+	}
+
+	if _, err := cli.VerifyAndParseWebhook(http.Header{}, raw); !errors.Is(err, kycerrors.ErrMissingSignature) {
+		t.Fatalf("expected ErrMissingSignature, got: %v", err)
+	}
+	if _, err := cli.VerifyAndParseWebhook(basic("hook", "wrong"), raw); !errors.Is(err, kycerrors.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got: %v", err)
+	}
+	if _, err := cli.VerifyAndParseWebhook(basic("hook", "pw"), []byte(`{"userReference":"user-1"}`)); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	unauthenticated, err := NewJumioClient(&config.Config{Jumio: config.JumioConfig{ClientID: "id", ClientSecret: "secret"}})
+	if err != nil {
+		t.Fatalf("NewJumioClient: %v", err)
+	}
+	if _, err := unauthenticated.VerifyAndParseWebhook(http.Header{}, raw); !errors.Is(err, kycerrors.ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig without webhook credentials, got: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"crypto/sha256"
+	"errors"
+	"sync"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/metrics"
+	"github.com/dq/kyc-sdk/model"
+)
+
+// recentWebhookSize 是用于识别重复回调的最近回调数量。
+const recentWebhookSize = 1024
+
+// WithMetrics 开启指标：每个 Client 方法的调用次数与耗时（按状态分类）、429 与重试次数、
+// Webhook 验签结果、事件类型与审核结论。Prometheus 实现见 metrics/prommetrics。
+func WithMetrics(recorder metrics.Recorder) Option {
+	return func(o *options) {
+		o.metrics = recorder
+	}
+}
+
+// observeWebhook 记录验签通过的回调。审核结论只统计 applicantReviewed 事件，
+// 与最近回调 body 完全相同的视为 Provider 重试，只计为 duplicate。
+func (c *Client) observeWebhook(rawBody []byte, payload *model.WebhookPayload) {
+	if c.recent != nil && c.recent.seen(rawBody) {
+		c.metrics.ObserveWebhook(c.name, metrics.WebhookDuplicate)
+		return
+	}
+
+	c.metrics.ObserveWebhook(c.name, metrics.WebhookOK)
+	c.metrics.IncWebhookEvent(c.name, payload.EventType())
+	if payload.EventType() == model.EventApplicantReviewed {
+		switch payload.ReviewResult {
+		case model.ResultGreen, model.ResultRed, model.ResultYellow:
+			c.metrics.IncReviewResult(c.name, payload.ReviewResult)
+		}
+	}
+}
+
+func webhookOutcome(err error) metrics.WebhookOutcome {
+	switch {
+	case errors.Is(err, kycerrors.ErrMissingSignature):
+		return metrics.WebhookMissing
+	case errors.Is(err, kycerrors.ErrInvalidSignature):
+		return metrics.WebhookInvalid
+	default:
+		return metrics.WebhookError
+	}
+}
+
+// recentWebhooks 以环形缓冲记录最近回调 body 的哈希。
+type recentWebhooks struct {
+	mu   sync.Mutex
+	set  map[[sha256.Size]byte]struct{}
+	ring [][sha256.Size]byte
+	next int
+}
+
+func newRecentWebhooks(size int) *recentWebhooks {
+	return &recentWebhooks{
+		set:  make(map[[sha256.Size]byte]struct{}, size),
+		ring: make([][sha256.Size]byte, 0, size),
+	}
+}
+
+// seen 返回 body 是否在最近的回调中出现过，并记录本次回调。
+func (r *recentWebhooks) seen(body []byte) bool {
+	sum := sha256.Sum256(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.set[sum]; ok {
+		return true
+	}
+
+	if len(r.ring) < cap(r.ring) {
+		r.ring = append(r.ring, sum)
+	} else {
+		delete(r.set, r.ring[r.next])
+		r.ring[r.next] = sum
+		r.next = (r.next + 1) % len(r.ring)
+	}
+	r.set[sum] = struct{}{}
+	return false
+}
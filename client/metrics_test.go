@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/dq/kyc-sdk/kyctest/fakesumsub"
+	"github.com/dq/kyc-sdk/metrics"
+	"github.com/dq/kyc-sdk/metrics/prommetrics"
+	"github.com/dq/kyc-sdk/model"
+)
+
+type countingRecorder struct {
+	metrics.Nop
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func (r *countingRecorder) ObserveRequest(provider, operation, statusClass string, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.requests == nil {
+		r.requests = make(map[string]int)
+	}
+	r.requests[provider+"/"+operation+"/"+statusClass]++
+}
+
+func TestWithMetrics_CustomRecorder(t *testing.T) {
+	srv := fakesumsub.New(fakesumsub.Options{})
+	defer srv.Close()
+
+	rec := &countingRecorder{}
+	cli, err := NewClient(srv.Config(), WithMetrics(rec))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := cli.CreateApplicant(context.Background(), "user-1"); err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	if _, err := cli.GetApplicant(context.Background(), "missing"); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if rec.requests["sumsub/CreateApplicant/2xx"] != 1 || rec.requests["sumsub/GetApplicant/4xx"] != 1 {
+		t.Fatalf("unexpected requests: %v", rec.requests)
+	}
+}
+
+func TestWithMetrics_Prometheus(t *testing.T) {
+	srv := fakesumsub.New(fakesumsub.Options{})
+	defer srv.Close()
+
+	reg := prometheus.NewRegistry()
+	rec, err := prommetrics.New(reg, prommetrics.Options{})
+	if err != nil {
+		t.Fatalf("prommetrics.New: %v", err)
+	}
+	cli, err := NewClient(srv.Config(), WithMetrics(rec))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	created, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	srv.InjectFault(fakesumsub.Fault{Status: http.StatusTooManyRequests, RetryAfter: time.Second, Times: 1})
+	if _, err := cli.GetApplicant(context.Background(), created.ApplicantID); err == nil {
+		t.Fatalf("expected rate limit error")
+	}
+
+	if err := srv.Review(created.ApplicantID, model.ResultRed, "FORGERY"); err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+	body := []byte(`{"type":"applicantReviewed","applicantId":"` + created.ApplicantID + `","externalUserId":"user-1","reviewStatus":"completed","reviewResult":{"reviewAnswer":"RED"}}`)
+	mac := hmac.New(sha256.New, []byte(fakesumsub.DefaultWebhookSecret))
+	mac.Write(body)
+	signed := http.Header{"X-Payload-Digest": {hex.EncodeToString(mac.Sum(nil))}}
+
+	for i := 0; i < 2; i++ {
+		if _, err := cli.VerifyAndParseWebhook(signed, body); err != nil {
+			t.Fatalf("VerifyAndParseWebhook: %v", err)
+		}
+	}
+	_, _ = cli.VerifyAndParseWebhook(http.Header{}, body)
+	_, _ = cli.VerifyAndParseWebhook(http.Header{"X-Payload-Digest": {"deadbeef"}}, body)
+
+	want := `
+# HELP kyc_rate_limited_total HTTP 429 responses from KYC providers.
+# TYPE kyc_rate_limited_total counter
+kyc_rate_limited_total{operation="GetApplicant",provider="sumsub",tenant=""} 1
+# HELP kyc_requests_total KYC provider calls by operation and status class.
+# TYPE kyc_requests_total counter
+kyc_requests_total{operation="CreateApplicant",provider="sumsub",status_class="2xx",tenant=""} 1
+kyc_requests_total{operation="GetApplicant",provider="sumsub",status_class="4xx",tenant=""} 1
+# HELP kyc_review_results_total Review results delivered by webhook.
+# TYPE kyc_review_results_total counter
+kyc_review_results_total{provider="sumsub",result="RED",tenant=""} 1
+# HELP kyc_webhook_events_total Verified webhook events by type.
+# TYPE kyc_webhook_events_total counter
+kyc_webhook_events_total{provider="sumsub",tenant="",type="applicantReviewed"} 1
+# HELP kyc_webhook_verifications_total Webhook verifications by outcome.
+# TYPE kyc_webhook_verifications_total counter
+kyc_webhook_verifications_total{outcome="duplicate",provider="sumsub",tenant=""} 1
+kyc_webhook_verifications_total{outcome="invalid",provider="sumsub",tenant=""} 1
+kyc_webhook_verifications_total{outcome="missing",provider="sumsub",tenant=""} 1
+kyc_webhook_verifications_total{outcome="ok",provider="sumsub",tenant=""} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"kyc_rate_limited_total", "kyc_requests_total", "kyc_review_results_total",
+		"kyc_webhook_events_total", "kyc_webhook_verifications_total",
+	); err != nil {
+		t.Fatal(err)
+	}
+}
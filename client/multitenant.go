@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dq/kyc-sdk/breaker"
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/metrics"
+	"github.com/dq/kyc-sdk/ratelimit"
+)
+
+// DefaultTenantHeader 是 Webhook 请求中标识租户的默认 header。
+const DefaultTenantHeader = "X-Kyc-Tenant"
+
+// maxWebhookBody 是 WebhookHandler 读取的最大 body 长度。
+const maxWebhookBody = 1 << 20
+
+type MultiTenantConfig struct {
+	// Tenants 是租户 key 到配置的映射，每个租户通常对应一个 Sumsub 账户。
+	Tenants map[string]*config.Config
+	// RateLimits 非空时为未设置 RateLimiter 的租户各创建一个限流器，租户之间互不影响。
+	RateLimits ratelimit.Limits
+	// WebhookPathPrefix 非空时从 Webhook 路径解析租户，例如前缀 /webhooks/sumsub/ 对应 /webhooks/sumsub/<tenant>。
+	WebhookPathPrefix string
+	// TenantHeader 是路径中没有租户时读取的 header，默认 DefaultTenantHeader。
+	TenantHeader string
+}
+
+// MultiTenant 在一个进程内管理多个租户（例如多个品牌各自的 Sumsub 账户）：
+// 每个租户一个独立的 Client，限流器、熔断器与 Webhook 验签密钥互不共享，
+// 日志、span 与指标（metrics.TenantRecorder）带上租户标识。
+type MultiTenant struct {
+	clients    map[string]*Client
+	names      []string
+	pathPrefix string
+	header     string
+}
+
+// NewMultiTenant 为每个租户创建 Client，opts 对所有租户生效。
+func NewMultiTenant(cfg MultiTenantConfig, opts ...Option) (*MultiTenant, error) {
+	if len(cfg.Tenants) == 0 {
+		return nil, fmt.Errorf("%w: multi-tenant requires tenants", kycerrors.ErrInvalidConfig)
+	}
+
+	limiters := make(map[*ratelimit.Limiter]string)
+	breakers := make(map[*breaker.Breaker]string)
+	for _, name := range sortedTenants(cfg.Tenants) {
+		tc := cfg.Tenants[name]
+		if tc == nil {
+			return nil, fmt.Errorf("%w: tenant %q has nil config", kycerrors.ErrInvalidConfig, name)
+		}
+		if tc.RateLimiter != nil {
+			if other, ok := limiters[tc.RateLimiter]; ok {
+				return nil, fmt.Errorf("%w: tenants %q and %q share a rate limiter", kycerrors.ErrInvalidConfig, other, name)
+			}
+			limiters[tc.RateLimiter] = name
+		}
+		if tc.CircuitBreaker != nil {
+			if other, ok := breakers[tc.CircuitBreaker]; ok {
+				return nil, fmt.Errorf("%w: tenants %q and %q share a circuit breaker", kycerrors.ErrInvalidConfig, other, name)
+			}
+			breakers[tc.CircuitBreaker] = name
+		}
+	}
+
+	o := buildOptions(opts)
+	m := &MultiTenant{
+		clients:    make(map[string]*Client, len(cfg.Tenants)),
+		names:      sortedTenants(cfg.Tenants),
+		pathPrefix: cfg.WebhookPathPrefix,
+		header:     cfg.TenantHeader,
+	}
+	if m.header == "" {
+		m.header = DefaultTenantHeader
+	}
+
+	for _, name := range m.names {
+		tc := cfg.Tenants[name]
+		if tc.RateLimiter == nil && cfg.RateLimits != nil {
+			c := *tc
+			c.RateLimiter = ratelimit.New(cfg.RateLimits)
+			tc = &c
+		}
+
+		// Clip 保证每个租户追加的选项不会写入调用方 opts 的底层数组。
+		cli, err := NewClient(tc, append(slices.Clip(opts), o.tenantOptions(name)...)...)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %q: %w", name, err)
+		}
+		cli.tenant = name
+		m.clients[name] = cli
+	}
+	return m, nil
+}
+
+// tenantOptions 让日志与指标带上租户标识。
+func (o options) tenantOptions(tenant string) []Option {
+	var out []Option
+	if o.logger != nil {
+		out = append(out, WithLogger(o.logger.With("tenant", tenant)))
+	}
+	if tr, ok := o.metrics.(metrics.TenantRecorder); ok {
+		out = append(out, WithMetrics(tr.WithTenant(tenant)))
+	}
+	return out
+}
+
+// Tenant 返回租户的 Client，租户不存在时返回 kycerrors.ErrUnknownTenant。
+func (m *MultiTenant) Tenant(tenant string) (*Client, error) {
+	if m == nil {
+		return nil, errors.New("nil multi-tenant client")
+	}
+	cli, ok := m.clients[tenant]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", kycerrors.ErrUnknownTenant, tenant)
+	}
+	return cli, nil
+}
+
+// Tenants 返回所有租户 key（已排序）。
+func (m *MultiTenant) Tenants() []string {
+	return append([]string(nil), m.names...)
+}
+
+// TenantFromRequest 从 Webhook 请求中解析租户：先取路径中 WebhookPathPrefix 之后的第一段，再取 TenantHeader。
+func (m *MultiTenant) TenantFromRequest(r *http.Request) (string, error) {
+	if m.pathPrefix != "" {
+		if rest, ok := strings.CutPrefix(r.URL.Path, m.pathPrefix); ok {
+			if tenant, _, _ := strings.Cut(rest, "/"); tenant != "" {
+				return tenant, nil
+			}
+		}
+	}
+	if tenant := strings.TrimSpace(r.Header.Get(m.header)); tenant != "" {
+		return tenant, nil
+	}
+	return "", fmt.Errorf("%w: no tenant in webhook request", kycerrors.ErrUnknownTenant)
+}
+
+// VerifyAndParseWebhook 解析租户并用该租户的密钥验签。
+func (m *MultiTenant) VerifyAndParseWebhook(r *http.Request, rawBody []byte) (tenant string, _ *WebhookPayload, err error) {
+	if m == nil {
+		return "", nil, errors.New("nil multi-tenant client")
+	}
+	tenant, err = m.TenantFromRequest(r)
+	if err != nil {
+		return "", nil, err
+	}
+	cli, err := m.Tenant(tenant)
+	if err != nil {
+		return tenant, nil, err
+	}
+	payload, err := cli.VerifyAndParseWebhookContext(r.Context(), r.Header, rawBody)
+	return tenant, payload, err
+}
+
+// WebhookHandler 返回处理多租户 Webhook 的 http.Handler：验签通过后在 kyc.DispatchWebhook 子 span 下调用 handle，
+// 未知租户返回 404，签名缺失或错误返回 401，其他验签 / 解析错误返回 400，handle 返回错误时返回 500（Provider 会重试）。
+func (m *MultiTenant) WebhookHandler(handle func(ctx context.Context, tenant string, payload *WebhookPayload) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+		if err != nil {
+			http.Error(w, "read body", http.StatusBadRequest)
+			return
+		}
+
+		tenant, payload, err := m.VerifyAndParseWebhook(r, body)
+		switch {
+		case errors.Is(err, kycerrors.ErrUnknownTenant):
+			http.Error(w, "unknown tenant", http.StatusNotFound)
+			return
+		case errors.Is(err, kycerrors.ErrMissingSignature), errors.Is(err, kycerrors.ErrInvalidSignature):
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		case err != nil:
+			http.Error(w, "invalid webhook", http.StatusBadRequest)
+			return
+		}
+
+		if err := m.dispatch(r.Context(), tenant, payload, handle); err != nil {
+			http.Error(w, "handler failed", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// dispatch 在 kyc.DispatchWebhook span 下调用 handle，span 记录租户、事件类型与 handle 的错误。
+func (m *MultiTenant) dispatch(ctx context.Context, tenant string, payload *WebhookPayload, handle func(ctx context.Context, tenant string, payload *WebhookPayload) error) (err error) {
+	cli := m.clients[tenant]
+	ctx, span := cli.tracer.Start(ctx, "kyc.DispatchWebhook", trace.WithAttributes(
+		attribute.String("kyc.provider", cli.name),
+		attribute.String("kyc.tenant", tenant),
+		attribute.String("kyc.webhook_type", payload.Type),
+		applicantAttr(payload.ApplicantID),
+	))
+	defer func() { endSpan(span, err) }()
+	return handle(ctx, tenant, payload)
+}
+
+func sortedTenants(tenants map[string]*config.Config) []string {
+	names := make([]string, 0, len(tenants))
+	for name := range tenants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/dq/kyc-sdk/breaker"
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/kyctest/fakesumsub"
+	"github.com/dq/kyc-sdk/metrics/prommetrics"
+	"github.com/dq/kyc-sdk/ratelimit"
+)
+
+func signedWebhook(t *testing.T, target, secret string, body []byte) *http.Request {
+	t.Helper()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	req.Header.Set("X-Payload-Digest", hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestMultiTenant_RoutesCallsAndWebhooks(t *testing.T) {
+	brandA := fakesumsub.New(fakesumsub.Options{AppToken: "token-a", SecretKey: "key-a", WebhookSecret: "hook-a"})
+	defer brandA.Close()
+	brandB := fakesumsub.New(fakesumsub.Options{AppToken: "token-b", SecretKey: "key-b", WebhookSecret: "hook-b"})
+	defer brandB.Close()
+
+	reg := prometheus.NewRegistry()
+	rec, err := prommetrics.New(reg, prommetrics.Options{})
+	if err != nil {
+		t.Fatalf("prommetrics.New: %v", err)
+	}
+	mt, err := NewMultiTenant(MultiTenantConfig{
+		Tenants:           map[string]*config.Config{"a": brandA.Config(), "b": brandB.Config()},
+		RateLimits:        ratelimit.DefaultLimits(),
+		WebhookPathPrefix: "/webhooks/",
+	}, WithMetrics(rec))
+	if err != nil {
+		t.Fatalf("NewMultiTenant: %v", err)
+	}
+
+	cliA, err := mt.Tenant("a")
+	if err != nil {
+		t.Fatalf("Tenant: %v", err)
+	}
+	if _, err := cliA.CreateApplicant(context.Background(), "user-1"); err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	if len(brandA.Requests()) != 1 || len(brandB.Requests()) != 0 {
+		t.Fatalf("expected call routed to tenant a only")
+	}
+	if _, err := mt.Tenant("c"); !errors.Is(err, kycerrors.ErrUnknownTenant) {
+		t.Fatalf("expected ErrUnknownTenant, got: %v", err)
+	}
+
+	var got []string
+	h := mt.WebhookHandler(func(_ context.Context, tenant string, payload *WebhookPayload) error {
+		got = append(got, tenant+":"+payload.ApplicantID)
+		return nil
+	})
+	body := []byte(`{"type":"applicantPending","applicantId":"a1"}`)
+
+	cases := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"path", signedWebhook(t, "/webhooks/b", "hook-b", body), http.StatusOK},
+		{"header", func() *http.Request {
+			r := signedWebhook(t, "/webhooks", "hook-a", body)
+			r.Header.Set(DefaultTenantHeader, "a")
+			return r
+		}(), http.StatusOK},
+		{"other tenant's secret", signedWebhook(t, "/webhooks/a", "hook-b", body), http.StatusUnauthorized},
+		{"unknown tenant", signedWebhook(t, "/webhooks/c", "hook-a", body), http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, tc.req)
+		if w.Code != tc.want {
+			t.Fatalf("%s: status %d, want %d", tc.name, w.Code, tc.want)
+		}
+	}
+	if strings.Join(got, ",") != "b:a1,a:a1" {
+		t.Fatalf("unexpected handled webhooks: %v", got)
+	}
+
+	want := `
+# HELP kyc_requests_total KYC provider calls by operation and status class.
+# TYPE kyc_requests_total counter
+kyc_requests_total{operation="CreateApplicant",provider="sumsub",status_class="2xx",tenant="a"} 1
+# HELP kyc_webhook_verifications_total Webhook verifications by outcome.
+# TYPE kyc_webhook_verifications_total counter
+kyc_webhook_verifications_total{outcome="invalid",provider="sumsub",tenant="a"} 1
+kyc_webhook_verifications_total{outcome="ok",provider="sumsub",tenant="a"} 1
+kyc_webhook_verifications_total{outcome="ok",provider="sumsub",tenant="b"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "kyc_requests_total", "kyc_webhook_verifications_total"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMultiTenant_RejectsSharedRateLimiter(t *testing.T) {
+	shared := ratelimit.New(ratelimit.DefaultLimits())
+	a := &config.Config{Provider: "sumsub", BaseURL: "https://a.example", AppToken: "a", SecretKey: "a", RateLimiter: shared}
+	b := &config.Config{Provider: "sumsub", BaseURL: "https://b.example", AppToken: "b", SecretKey: "b", RateLimiter: shared}
+
+	_, err := NewMultiTenant(MultiTenantConfig{Tenants: map[string]*config.Config{"a": a, "b": b}})
+	if !errors.Is(err, kycerrors.ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got: %v", err)
+	}
+}
+
+func TestMultiTenant_RejectsSharedCircuitBreaker(t *testing.T) {
+	shared := breaker.New(breaker.Settings{Name: "sumsub"})
+	a := &config.Config{Provider: "sumsub", BaseURL: "https://a.example", AppToken: "a", SecretKey: "a", CircuitBreaker: shared}
+	b := &config.Config{Provider: "sumsub", BaseURL: "https://b.example", AppToken: "b", SecretKey: "b", CircuitBreaker: shared}
+
+	_, err := NewMultiTenant(MultiTenantConfig{Tenants: map[string]*config.Config{"a": a, "b": b}})
+	if !errors.Is(err, kycerrors.ErrInvalidConfig) || !strings.Contains(err.Error(), "circuit breaker") {
+		t.Fatalf("expected ErrInvalidConfig for shared circuit breaker, got: %v", err)
+	}
+}
+
+func TestMultiTenant_CircuitBreakerGaugePerTenant(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	rec, err := prommetrics.New(reg, prommetrics.Options{})
+	if err != nil {
+		t.Fatalf("prommetrics.New: %v", err)
+	}
+
+	// 两个租户的熔断器同名，gauge 依靠 tenant 标签区分。
+	tenants := map[string]*config.Config{}
+	recorders := map[string]*prommetrics.Recorder{}
+	for _, name := range []string{"a", "b"} {
+		recorders[name] = rec.WithTenant(name).(*prommetrics.Recorder)
+		tenants[name] = &config.Config{
+			Provider: "sumsub", BaseURL: "https://" + name + ".example", AppToken: name, SecretKey: name, WebhookSecret: name,
+			CircuitBreaker: breaker.New(breaker.Settings{Name: "sumsub", OnStateChange: recorders[name].CircuitStateChanged}),
+		}
+	}
+	if _, err := NewMultiTenant(MultiTenantConfig{Tenants: tenants}, WithMetrics(rec)); err != nil {
+		t.Fatalf("NewMultiTenant: %v", err)
+	}
+
+	recorders["a"].CircuitStateChanged("sumsub", breaker.StateClosed, breaker.StateOpen)
+	recorders["b"].CircuitStateChanged("sumsub", breaker.StateOpen, breaker.StateClosed)
+
+	want := `
+# HELP kyc_circuit_breaker_state Circuit breaker state: 0 closed, 1 half-open, 2 open.
+# TYPE kyc_circuit_breaker_state gauge
+kyc_circuit_breaker_state{breaker="sumsub",tenant="a"} 2
+kyc_circuit_breaker_state{breaker="sumsub",tenant="b"} 0
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "kyc_circuit_breaker_state"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMultiTenant_DoesNotWriteCallerOptions(t *testing.T) {
+	a := &config.Config{Provider: "sumsub", BaseURL: "https://a.example", AppToken: "a", SecretKey: "a", WebhookSecret: "a"}
+	b := &config.Config{Provider: "sumsub", BaseURL: "https://b.example", AppToken: "b", SecretKey: "b", WebhookSecret: "b"}
+
+	// 有空余容量的 opts：追加租户选项时不得写入调用方的底层数组。
+	opts := make([]Option, 1, 8)
+	opts[0] = WithLogger(slog.New(slog.DiscardHandler))
+	if _, err := NewMultiTenant(MultiTenantConfig{Tenants: map[string]*config.Config{"a": a, "b": b}}, opts...); err != nil {
+		t.Fatalf("NewMultiTenant: %v", err)
+	}
+	for i, o := range opts[1:cap(opts)] {
+		if o != nil {
+			t.Fatalf("caller's opts backing array was written at index %d", i+1)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func TestNewOnfidoClient_InvalidConfig(t *testing.T) {
+	if _, err := NewOnfidoClient(&config.Config{}); !errors.Is(err, kycerrors.ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got: %v", err)
+	}
+}
+
+func TestOnfidoClient_GenerateLink(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token token=api-token" {
+			t.Fatalf("unexpected Authorization: %s", r.Header.Get("Authorization"))
+		}
+
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+
+		switch r.URL.Path {
+		case "/v3.6/applicants":
+			if got["email"] != "a@b.com" {
+				t.Fatalf("email mismatch: %v", got["email"])
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "ap-1"})
+		case "/v3.6/workflow_runs":
+			if got["workflow_id"] != "wf-1" || got["applicant_id"] != "ap-1" || got["customer_user_id"] != "user-1" {
+				t.Fatalf("workflow run mismatch: %v", got)
+			}
+			link, ok := got["link"].(map[string]any)
+			if !ok || link["completed_redirect_url"] != "https://ok" || link["expires_at"] == "" {
+				t.Fatalf("link mismatch: %v", got["link"])
+			}
+			_, _ = w.Write([]byte(`{"id":"run-1","link":{"url":"https://onfido/link"}}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := NewOnfidoClient(&config.Config{
+		Onfido: config.OnfidoConfig{BaseURL: srv.URL, APIToken: "api-token", WorkflowID: "wf-1"},
+	})
+	if err != nil {
+		t.Fatalf("NewOnfidoClient: %v", err)
+	}
+
+	url, err := cli.GenerateLink(context.Background(), GenerateLinkRequest{
+		UserID:     "user-1",
+		Email:      "a@b.com",
+		SuccessURL: "https://ok",
+	})
+	if err != nil {
+		t.Fatalf("GenerateLink: %v", err)
+	}
+	if url != "https://onfido/link" {
+		t.Fatalf("url mismatch: %s", url)
+	}
+}
+
+func TestOnfidoClient_ReusesApplicant(t *testing.T) {
+	var created int
+	runs := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&got)
+
+		switch r.URL.Path {
+		case "/v3.6/applicants":
+			created++
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": fmt.Sprintf("ap-%d", created)})
+		case "/v3.6/workflow_runs":
+			runs[got["applicant_id"].(string)]++
+			_, _ = w.Write([]byte(`{"id":"run-1","link":{"url":"https://onfido/link"}}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := NewOnfidoClient(&config.Config{
+		Onfido: config.OnfidoConfig{BaseURL: srv.URL, APIToken: "api-token", WorkflowID: "wf-1"},
+	})
+	if err != nil {
+		t.Fatalf("NewOnfidoClient: %v", err)
+	}
+	ctx := context.Background()
+
+	info, err := cli.CreateApplicant(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	for range 2 {
+		if _, err := cli.GenerateLink(ctx, GenerateLinkRequest{UserID: "user-1"}); err != nil {
+			t.Fatalf("GenerateLink: %v", err)
+		}
+	}
+	if created != 1 || runs[info.ApplicantID] != 2 {
+		t.Fatalf("expected one applicant reused by both runs, created=%d runs=%v", created, runs)
+	}
+
+	if _, err := cli.GenerateLink(ctx, GenerateLinkRequest{UserID: "user-2", ApplicantID: "ap-existing"}); err != nil {
+		t.Fatalf("GenerateLink: %v", err)
+	}
+	if created != 1 || runs["ap-existing"] != 1 {
+		t.Fatalf("expected existing applicant to be used, created=%d runs=%v", created, runs)
+	}
+}
+
+func TestOnfidoClient_GetApplicant(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3.6/checks" || r.URL.Query().Get("applicant_id") != "ap-1" {
+			t.Fatalf("unexpected request: %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"checks":[{"id":"chk-2","status":"complete","result":"clear"},{"id":"chk-1","status":"complete","result":"consider"}]}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewOnfidoClient(&config.Config{Onfido: config.OnfidoConfig{BaseURL: srv.URL, APIToken: "api-token"}})
+	if err != nil {
+		t.Fatalf("NewOnfidoClient: %v", err)
+	}
+
+	info, err := cli.GetApplicant(context.Background(), "ap-1")
+	if err != nil {
+		t.Fatalf("GetApplicant: %v", err)
+	}
+	if info.Status != model.StatusReviewed || info.Result != model.ResultGreen || info.Provider != "onfido" {
+		t.Fatalf("applicant mismatch: %+v", info)
+	}
+}
+
+func TestOnfidoClient_VerifyAndParseWebhook(t *testing.T) {
+	// 与真实 Onfido 回调一致：object 只有 id、status、completed_at_iso8601 与 href。
+	raw := []byte(`{"payload":{"resource_type":"workflow_run","action":"workflow_run.completed","object":{"id":"run-1","status":"declined","completed_at_iso8601":"2026-10-19T08:00:00Z","href":"https://api.eu.onfido.com/v3.6/workflow_runs/run-1"}}}`)
+	token := "webhook-token"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v3.6/workflow_runs/run-1" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Token token=api-token" {
+			t.Fatalf("unexpected Authorization: %s", r.Header.Get("Authorization"))
+		}
+		_, _ = w.Write([]byte(`{"id":"run-1","applicant_id":"ap-1","workflow_id":"wf-1","customer_user_id":"user-1","status":"declined"}`))
+	}))
+	defer srv.Close()
+
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write(raw)
+	sig := hex.EncodeToString(mac.Sum(nil))
+
+	cli, err := NewOnfidoClient(&config.Config{Onfido: config.OnfidoConfig{BaseURL: srv.URL, APIToken: "api-token", WebhookToken: token}})
+	if err != nil {
+		t.Fatalf("NewOnfidoClient: %v", err)
+	}
+
+	h := http.Header{}
+	h.Set("X-SHA2-Signature", sig)
+	payload, err := cli.VerifyAndParseWebhook(h, raw)
+	if err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if payload.EventType() != model.EventApplicantReviewed || payload.ApplicantID != "ap-1" || payload.ExternalUserID != "user-1" || payload.ReviewResult != model.ResultRed {
+		t.Fatalf("payload mismatch: %+v", payload)
+	}
+
+	h.Set("X-SHA2-Signature", "deadbeef")
+	if _, err := cli.VerifyAndParseWebhook(h, raw); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestOnfidoClient_VerifyAndParseWebhook_CheckCompleted(t *testing.T) {
+	raw := []byte(`{"payload":{"resource_type":"check","action":"check.completed","object":{"id":"chk-1","status":"complete","completed_at_iso8601":"2026-10-19T08:00:00Z","href":"https://api.eu.onfido.com/v3.6/checks/chk-1"}}}`)
+	token := "webhook-token"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3.6/checks/chk-1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"chk-1","applicant_id":"ap-1","status":"complete","result":"consider"}`))
+	}))
+	defer srv.Close()
+
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write(raw)
+
+	cli, err := NewOnfidoClient(&config.Config{Onfido: config.OnfidoConfig{BaseURL: srv.URL, APIToken: "api-token", WebhookToken: token}})
+	if err != nil {
+		t.Fatalf("NewOnfidoClient: %v", err)
+	}
+	payload, err := cli.VerifyAndParseWebhookContext(context.Background(), http.Header{"X-Sha2-Signature": {hex.EncodeToString(mac.Sum(nil))}}, raw)
+	if err != nil {
+		t.Fatalf("VerifyAndParseWebhookContext: %v", err)
+	}
+	if payload.ApplicantID != "ap-1" || payload.ReviewStatus != "complete" || payload.ReviewResult != model.ResultYellow {
+		t.Fatalf("payload mismatch: %+v", payload)
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/internal/httpclient"
+	"github.com/dq/kyc-sdk/internal/httplog"
+	"github.com/dq/kyc-sdk/metrics"
+)
+
+// Option 定制 Client，传给 New / NewClient / New*Client。
+type Option func(*options)
+
+type options struct {
+	logger         *slog.Logger
+	tracerProvider trace.TracerProvider
+	metrics        metrics.Recorder
+}
+
+// WithLogger 开启结构化日志。通过 NewClient / New*Client 创建时，每次 Provider HTTP 调用记录 method、path、status、
+// 耗时、correlation id 与尝试次数，成功记 Info、失败记 Warn；Debug 级别额外记录脱敏后的请求 / 响应 body。
+// 邮箱、手机号、姓名、token 与签名会被替换为 "REDACTED"。
+// New 接收的是已创建好的 Provider，无法替换其 Transport，此时只记录 Client 自身的日志（例如 Webhook 验签失败）。
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+func buildOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// clientLogger 返回 Client 自身使用的 logger，未设置时丢弃日志。
+func (o options) clientLogger() *slog.Logger {
+	if o.logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return o.logger
+}
+
+func (o options) clientMetrics() metrics.Recorder {
+	if o.metrics == nil {
+		return metrics.Nop{}
+	}
+	return o.metrics
+}
+
+func (o options) clientTracer() trace.Tracer {
+	if o.tracerProvider == nil {
+		return noopTracer()
+	}
+	return o.tracerProvider.Tracer(instrumentationName)
+}
+
+// providerConfig 返回交给 Provider 工厂的配置：需要包装 Transport 时复制一份，不修改调用方的 cfg。
+// 追踪在最外层，日志因此可以从请求 context 中拿到 HTTP span。
+func (o options) providerConfig(name string, cfg *config.Config) *config.Config {
+	if o.logger == nil && o.tracerProvider == nil && o.metrics == nil {
+		return cfg
+	}
+
+	c := *cfg
+	if o.metrics != nil {
+		c.Transport = httpclient.NewMetricsTransport(c.Transport, o.metrics, name)
+	}
+	if o.logger != nil {
+		c.Transport = httplog.NewTransport(c.Transport, o.logger, name)
+	}
+	if o.tracerProvider != nil {
+		c.Transport = httpclient.NewTracingTransport(c.Transport, o.tracerProvider.Tracer(instrumentationName), name)
+	}
+	return &c
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dq/kyc-sdk/kyctest/fakesumsub"
+)
+
+func TestWithLogger_LogsCallsWithRedaction(t *testing.T) {
+	srv := fakesumsub.New(fakesumsub.Options{})
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	cfg := srv.Config()
+	cli, err := NewClient(cfg, WithLogger(logger))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if cfg.Transport != nil {
+		t.Fatalf("NewClient must not modify the caller's config")
+	}
+
+	if _, err := cli.GenerateLink(context.Background(), GenerateLinkRequest{UserID: "user-1", LevelName: "basic", Email: "alice@example.com", Phone: "+441234567890"}); err != nil {
+		t.Fatalf("GenerateLink: %v", err)
+	}
+
+	srv.InjectFault(fakesumsub.Fault{Status: http.StatusInternalServerError, Times: 1})
+	if _, err := cli.GetApplicant(context.Background(), "missing"); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`"method":"POST"`,
+		`"path":"/resources/sdkIntegrations/levels/-/websdkLink"`,
+		`"status":200`,
+		`"attempt":1`,
+		`"latency":`,
+		`"level":"WARN"`,
+		`"status":500`,
+		`"correlation_id":"fake-`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("log missing %s:\n%s", want, out)
+		}
+	}
+	for _, leak := range []string{"alice@example.com", "+441234567890", fakesumsub.DefaultAppToken} {
+		if strings.Contains(out, leak) {
+			t.Fatalf("log leaks %q:\n%s", leak, out)
+		}
+	}
+}
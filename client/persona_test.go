@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func personaSig(secret string, ts int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts, 10) + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestPersonaClient_CreateApplicant(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/inquiries" || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Fatalf("unexpected Authorization: %s", r.Header.Get("Authorization"))
+		}
+
+		var got struct {
+			Data struct {
+				Attributes map[string]string `json:"attributes"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got.Data.Attributes["inquiry-template-id"] != "itmpl_1" || got.Data.Attributes["reference-id"] != "user-1" {
+			t.Fatalf("attributes mismatch: %v", got.Data.Attributes)
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"type":"inquiry","id":"inq_1","attributes":{"status":"created","reference-id":"user-1"}}}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewPersonaClient(&config.Config{Persona: config.PersonaConfig{BaseURL: srv.URL, APIKey: "key", TemplateID: "itmpl_1"}})
+	if err != nil {
+		t.Fatalf("NewPersonaClient: %v", err)
+	}
+
+	info, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	if info.ApplicantID != "inq_1" || info.UserID != "user-1" || info.Status != model.StatusPending || info.Provider != "persona" {
+		t.Fatalf("applicant mismatch: %+v", info)
+	}
+}
+
+func TestPersonaClient_GenerateLink(t *testing.T) {
+	cli, err := NewPersonaClient(&config.Config{Persona: config.PersonaConfig{APIKey: "key", TemplateID: "itmpl_1", EnvironmentID: "env_1"}})
+	if err != nil {
+		t.Fatalf("NewPersonaClient: %v", err)
+	}
+
+	link, err := cli.GenerateLink(context.Background(), GenerateLinkRequest{UserID: "user-1", SuccessURL: "https://ok"})
+	if err != nil {
+		t.Fatalf("GenerateLink: %v", err)
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("parse link: %v", err)
+	}
+	q := u.Query()
+	if u.Host != "withpersona.com" || q.Get("reference-id") != "user-1" || q.Get("inquiry-template-id") != "itmpl_1" || q.Get("redirect-uri") != "https://ok" || q.Get("environment-id") != "env_1" {
+		t.Fatalf("link mismatch: %s", link)
+	}
+}
+
+func TestPersonaClient_GetApplicant_StatusMapping(t *testing.T) {
+	cases := map[string]struct {
+		status model.KycStatus
+		result model.KycResult
+	}{
+		"created":      {model.StatusPending, model.ResultNone},
+		"pending":      {model.StatusPending, model.ResultNone},
+		"completed":    {model.StatusReviewed, model.ResultGreen},
+		"approved":     {model.StatusReviewed, model.ResultGreen},
+		"declined":     {model.StatusReviewed, model.ResultRed},
+		"needs_review": {model.StatusPending, model.ResultYellow},
+	}
+
+	for status, want := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v1/inquiries/inq_1" {
+				t.Fatalf("unexpected path: %s", r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`{"data":{"type":"inquiry","id":"inq_1","attributes":{"status":"` + status + `","reference-id":"user-1"}}}`))
+		}))
+
+		cli, err := NewPersonaClient(&config.Config{Persona: config.PersonaConfig{BaseURL: srv.URL, APIKey: "key"}})
+		if err != nil {
+			t.Fatalf("NewPersonaClient: %v", err)
+		}
+		info, err := cli.GetApplicant(context.Background(), "inq_1")
+		srv.Close()
+		if err != nil {
+			t.Fatalf("GetApplicant(%s): %v", status, err)
+		}
+		if info.Status != want.status || info.Result != want.result {
+			t.Fatalf("%s: got %s/%s, want %s/%s", status, info.Status, info.Result, want.status, want.result)
+		}
+	}
+}
+
+func TestPersonaClient_VerifyAndParseWebhook(t *testing.T) {
+	raw := []byte(`{"data":{"type":"event","id":"evt_1","attributes":{"name":"inquiry.approved","payload":{"data":{"type":"inquiry","id":"inq_1","attributes":{"status":"approved","reference-id":"user-1"}}}}}}`)
+	secret := "wh-secret"
+
+	cli, err := NewPersonaClient(&config.Config{Persona: config.PersonaConfig{APIKey: "key", WebhookSecret: secret}})
+	if err != nil {
+		t.Fatalf("NewPersonaClient: %v", err)
+	}
+
+	now := time.Now().Unix()
+	ts := strconv.FormatInt(now, 10)
+
+	// 轮换 secret 期间 Persona 会同时携带旧 secret 与新 secret 的签名。
+	h := http.Header{}
+	h.Set("Persona-Signature", "t="+ts+",v1="+personaSig("old-secret", now, raw)+" t="+ts+",v1="+personaSig(secret, now, raw))
+	payload, err := cli.VerifyAndParseWebhook(h, raw)
+	if err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if payload.EventType() != model.EventApplicantReviewed || payload.ApplicantID != "inq_1" || payload.ExternalUserID != "user-1" || payload.ReviewResult != model.ResultGreen {
+		t.Fatalf("payload mismatch: %+v", payload)
+	}
+
+	old := now - 3600
+	h.Set("Persona-Signature", "t="+strconv.FormatInt(old, 10)+",v1="+personaSig(secret, old, raw))
+	if _, err := cli.VerifyAndParseWebhook(h, raw); err == nil {
+		t.Fatalf("expected error for timestamp outside tolerance")
+	}
+
+	h.Set("Persona-Signature", "t="+ts+",v1="+personaSig("other", now, raw))
+	if _, err := cli.VerifyAndParseWebhook(h, raw); err == nil {
+		t.Fatalf("expected error for invalid signature")
+	}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+type Questionnaire = model.Questionnaire
+
+// QuestionnaireProvider 是支持读写 applicant 问卷答案的 Provider 需要额外实现的接口。
+type QuestionnaireProvider interface {
+	GetQuestionnaire(ctx context.Context, applicantID, questionnaireID string) (*model.Questionnaire, error)
+	SubmitQuestionnaire(ctx context.Context, applicantID string, q model.Questionnaire) error
+}
+
+func (c *Client) GetQuestionnaire(ctx context.Context, applicantID, questionnaireID string, opts ...CallOption) (_ *model.Questionnaire, err error) {
+	p, err := c.questionnaireProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "GetQuestionnaire", opts, applicantAttr(applicantID))
+	defer func() { call.end(err) }()
+
+	return p.GetQuestionnaire(ctx, applicantID, questionnaireID)
+}
+
+// SubmitQuestionnaire 写入（预填）问卷答案，已有答案会被覆盖。
+func (c *Client) SubmitQuestionnaire(ctx context.Context, applicantID string, q Questionnaire, opts ...CallOption) (err error) {
+	p, err := c.questionnaireProvider()
+	if err != nil {
+		return err
+	}
+	ctx, call := c.begin(ctx, "SubmitQuestionnaire", opts, applicantAttr(applicantID))
+	defer func() { call.end(err) }()
+
+	return p.SubmitQuestionnaire(ctx, applicantID, q)
+}
+
+func (c *Client) questionnaireProvider() (QuestionnaireProvider, error) {
+	if c == nil || c.provider == nil {
+		return nil, errors.New("nil client")
+	}
+	p, ok := c.provider.(QuestionnaireProvider)
+	if !ok {
+		return nil, kycerrors.ErrNotSupported
+	}
+	return p, nil
+}
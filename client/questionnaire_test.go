@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func TestClient_GetQuestionnaire(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/applicants/a1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"a1","questionnaires":[{"id":"sof","sections":{"funds":{"items":{"source":{"value":"salary"},"countries":{"values":["SGP","HKG"]}}}}}]}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	q, err := cli.GetQuestionnaire(context.Background(), "a1", "sof")
+	if err != nil {
+		t.Fatalf("GetQuestionnaire: %v", err)
+	}
+	if q.ID != "sof" || len(q.Answers) != 2 {
+		t.Fatalf("questionnaire mismatch: %+v", q)
+	}
+	// 答案按 section / item 排序，保证输出稳定。
+	if q.Answers[0].Item != "countries" || len(q.Answers[0].Values) != 2 {
+		t.Fatalf("answer[0] mismatch: %+v", q.Answers[0])
+	}
+	if q.Answers[1].Item != "source" || q.Answers[1].Value != "salary" {
+		t.Fatalf("answer[1] mismatch: %+v", q.Answers[1])
+	}
+
+	if _, err := cli.GetQuestionnaire(context.Background(), "a1", "missing"); !errors.Is(err, kycerrors.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+}
+
+func TestClient_SubmitQuestionnaire(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/applicants/a1/questionnaires" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+
+		var got struct {
+			ID       string `json:"id"`
+			Sections map[string]struct {
+				Items map[string]struct {
+					Value string `json:"value"`
+				} `json:"items"`
+			} `json:"sections"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got.ID != "sof" || got.Sections["funds"].Items["source"].Value != "business" {
+			t.Fatalf("body mismatch: %+v", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	err = cli.SubmitQuestionnaire(context.Background(), "a1", Questionnaire{
+		ID: "sof",
+		Answers: []model.QuestionnaireAnswer{
+			{Section: "funds", Item: "source", Value: "business"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SubmitQuestionnaire: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/internal/jumio"
+	"github.com/dq/kyc-sdk/internal/onfido"
+	"github.com/dq/kyc-sdk/internal/persona"
+	"github.com/dq/kyc-sdk/internal/sumsub"
+	"github.com/dq/kyc-sdk/internal/veriff"
+)
+
+// DefaultProvider 是 config.Config.Provider 为空时使用的 Provider。
+const DefaultProvider = "sumsub"
+
+// Factory 根据配置创建一个 Provider。
+type Factory func(cfg *config.Config) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+func init() {
+	Register("sumsub", func(cfg *config.Config) (Provider, error) { return sumsub.New(cfg) })
+	Register("onfido", func(cfg *config.Config) (Provider, error) { return onfido.New(cfg) })
+	Register("veriff", func(cfg *config.Config) (Provider, error) { return veriff.New(cfg) })
+	Register("jumio", func(cfg *config.Config) (Provider, error) { return jumio.New(cfg) })
+	Register("persona", func(cfg *config.Config) (Provider, error) { return persona.New(cfg) })
+}
+
+// Register 以 name 注册一个 Provider 工厂，之后可通过 config.Config.Provider = name 选择。
+// 与 database/sql.Register 一致：name 为空、factory 为 nil 或重复注册都会 panic，
+// 通常在 init 中调用。
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if name == "" {
+		panic("kyc-sdk: Register provider with empty name")
+	}
+	if factory == nil {
+		panic("kyc-sdk: Register provider " + name + " with nil factory")
+	}
+	if _, dup := registry[name]; dup {
+		panic("kyc-sdk: Register called twice for provider " + name)
+	}
+	registry[name] = factory
+}
+
+// Providers 返回已注册的 Provider 名称（按字母序）。
+func Providers() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookup(name string) (Factory, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	f, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q", name)
+	}
+	return f, nil
+}
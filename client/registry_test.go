@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+)
+
+func TestRegister_ConfigDrivenSelection(t *testing.T) {
+	var gotCfg *config.Config
+	Register("registry-test", func(cfg *config.Config) (Provider, error) {
+		gotCfg = cfg
+		return basicProvider{}, nil
+	})
+
+	cfg := &config.Config{Provider: "registry-test"}
+	cli, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if gotCfg != cfg {
+		t.Fatalf("factory should receive the config")
+	}
+
+	info, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	if info.Provider != "registry-test" {
+		t.Fatalf("expected Provider from registry name, got %q", info.Provider)
+	}
+
+	found := false
+	for _, name := range Providers() {
+		if name == "registry-test" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected registry-test in Providers(): %v", Providers())
+	}
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic on duplicate registration")
+		}
+	}()
+	Register("sumsub", func(cfg *config.Config) (Provider, error) { return basicProvider{}, nil })
+}
+
+func TestNewClient_UnknownProvider(t *testing.T) {
+	if _, err := NewClient(&config.Config{Provider: "nope"}); !errors.Is(err, kycerrors.ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got: %v", err)
+	}
+}
+
+func TestNewClient_BuiltinProviders(t *testing.T) {
+	for _, name := range []string{"sumsub", "onfido", "veriff", "jumio", "persona"} {
+		// 空配置下每个内置 Provider 都应给出配置错误，而不是未注册错误。
+		_, err := NewClient(&config.Config{Provider: name})
+		if !errors.Is(err, kycerrors.ErrInvalidConfig) {
+			t.Fatalf("%s: expected ErrInvalidConfig, got: %v", name, err)
+		}
+	}
+}
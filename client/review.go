@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+// ReviewSimulator 是支持在 sandbox 中强制审核结论的 Provider 需要额外实现的接口。
+type ReviewSimulator interface {
+	SimulateReview(ctx context.Context, applicantID string, answer model.KycResult, rejectLabels []string) error
+}
+
+// SimulateReview 在 sandbox 中把 applicant 的审核结论强制为 answer（GREEN / RED），
+// 用于联调下游流程；Provider 随后会像真实审核一样发送 applicantReviewed Webhook。
+// 只能在 sandbox 环境调用，production 或无法确定环境时返回 kycerrors.ErrSandboxOnly。
+func (c *Client) SimulateReview(ctx context.Context, applicantID string, answer model.KycResult, rejectLabels []string, opts ...CallOption) (err error) {
+	p, err := c.reviewSimulator()
+	if err != nil {
+		return err
+	}
+	if err := c.requireSandbox("SimulateReview"); err != nil {
+		return err
+	}
+	ctx, call := c.begin(ctx, "SimulateReview", opts, applicantAttr(applicantID), resultAttr(answer))
+	defer func() { call.end(err) }()
+
+	switch answer {
+	case model.ResultGreen, model.ResultRed:
+	default:
+		return fmt.Errorf("%w: unsupported review answer %q", kycerrors.ErrBadRequest, answer)
+	}
+	return p.SimulateReview(ctx, applicantID, answer, rejectLabels)
+}
+
+func (c *Client) reviewSimulator() (ReviewSimulator, error) {
+	if c == nil || c.provider == nil {
+		return nil, errors.New("nil client")
+	}
+	p, ok := c.provider.(ReviewSimulator)
+	if !ok {
+		return nil, kycerrors.ErrNotSupported
+	}
+	return p, nil
+}
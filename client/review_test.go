@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/kyctest/fakesumsub"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func TestClient_SimulateReview(t *testing.T) {
+	const token = "sbx:fake-app-token"
+	srv := fakesumsub.New(fakesumsub.Options{AppToken: token})
+	defer srv.Close()
+
+	cli, err := NewClient(srv.Config())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	info, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+
+	if err := cli.SimulateReview(context.Background(), info.ApplicantID, model.ResultRed, []string{"FORGERY"}); err != nil {
+		t.Fatalf("SimulateReview: %v", err)
+	}
+	a, _ := srv.Applicant(info.ApplicantID)
+	if a.ReviewAnswer != "RED" || !slices.Equal(a.RejectLabels, []string{"FORGERY"}) {
+		t.Fatalf("unexpected review state: %+v", a)
+	}
+
+	got, err := cli.GetApplicant(context.Background(), info.ApplicantID)
+	if err != nil {
+		t.Fatalf("GetApplicant: %v", err)
+	}
+	if got.Result != model.ResultRed {
+		t.Fatalf("expected RED, got %s", got.Result)
+	}
+
+	if err := cli.SimulateReview(context.Background(), info.ApplicantID, model.ResultYellow, nil); !errors.Is(err, kycerrors.ErrBadRequest) {
+		t.Fatalf("expected ErrBadRequest for YELLOW, got: %v", err)
+	}
+}
+
+func TestClient_SimulateReview_RefusesProduction(t *testing.T) {
+	for name, token := range map[string]string{
+		"production": "prd:fake-app-token",
+		"unknown":    fakesumsub.DefaultAppToken,
+	} {
+		srv := fakesumsub.New(fakesumsub.Options{AppToken: token})
+		defer srv.Close()
+
+		cli, err := NewClient(srv.Config())
+		if err != nil {
+			t.Fatalf("%s: NewClient: %v", name, err)
+		}
+		if err := cli.SimulateReview(context.Background(), "a1", model.ResultGreen, nil); !errors.Is(err, kycerrors.ErrSandboxOnly) {
+			t.Fatalf("%s: expected ErrSandboxOnly, got: %v", name, err)
+		}
+		if n := len(srv.Requests()); n != 0 {
+			t.Fatalf("%s: expected no requests, got %d", name, n)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+// Route 是一条路由规则，所有非空条件同时满足时命中。规则按顺序匹配，先命中者生效。
+type Route struct {
+	Provider  string   // 目标 Provider 名称（RouterConfig.Providers 中的 key）
+	Countries []string // 国家列表（ISO 3166-1 alpha-3），空表示不限
+	Levels    []string // level 列表，空表示不限
+	Segments  []string // 用户分群列表，空表示不限
+	// Percent 按 userID 哈希分桶（0-99），桶号小于 Percent 时命中；0 表示不限。
+	// 桶号对每条规则相同，因此做 A/B 分流时 Percent 是累计值，例如 20/30/50 的分流应写成 20、50、100。
+	Percent int
+}
+
+// OwnerStore 记录 applicant / 用户归属于哪个 Provider，使后续查询与 Webhook 落到同一个 Provider。
+// 多实例部署时应使用共享存储（例如 Redis）实现。
+type OwnerStore interface {
+	Owner(key string) (provider string, ok bool)
+	SetOwner(key, provider string)
+}
+
+// MemoryOwnerStore 是基于内存的 OwnerStore，仅适用于单实例。
+type MemoryOwnerStore struct {
+	mu     sync.RWMutex
+	owners map[string]string
+}
+
+func NewMemoryOwnerStore() *MemoryOwnerStore {
+	return &MemoryOwnerStore{owners: make(map[string]string)}
+}
+
+func (s *MemoryOwnerStore) Owner(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.owners[key]
+	return p, ok
+}
+
+func (s *MemoryOwnerStore) SetOwner(key, provider string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.owners[key] = provider
+}
+
+// WebhookSignatureProvider 是参与 Router Webhook 分发的 Provider 需要额外实现的接口，
+// 返回回调携带签名（或认证信息）的 header。SDK 内置的 Provider 与 Client.AsProvider 均已实现。
+type WebhookSignatureProvider interface {
+	WebhookSignatureHeader() string
+}
+
+type RouterConfig struct {
+	Providers map[string]Provider
+	Routes    []Route
+	Default   string     // 未命中任何规则时使用的 Provider
+	Fallbacks []string   // GenerateLink 遇到 5xx / 超时 / 熔断时按顺序尝试的 Provider
+	Owners    OwnerStore // 为空时使用 MemoryOwnerStore
+}
+
+// Router 把多个 Provider 组合成一个 Provider：
+// - 按国家 / level / 分群 / 百分比规则为新用户选择 Provider，同一用户之后固定使用同一个 Provider
+// - 记录 applicant 归属，GetApplicant 落到创建它的 Provider
+// - Webhook 按签名 header 交给对应的 Provider 验签
+// - GenerateLink 在 ErrServerInternal、超时或 ErrProviderUnavailable（熔断）时依次尝试 Fallbacks
+//
+// Router 只实现基础 Provider 接口，KYB / AML 等可选能力请直接使用对应 Provider 的 Client。
+type Router struct {
+	providers map[string]Provider
+	names     []string
+	routes    []Route
+	def       string
+	fallbacks []string
+	owners    OwnerStore
+}
+
+func NewRouter(cfg RouterConfig) (*Router, error) {
+	if len(cfg.Providers) == 0 {
+		return nil, fmt.Errorf("%w: router requires providers", kycerrors.ErrInvalidConfig)
+	}
+	if _, ok := cfg.Providers[cfg.Default]; !ok {
+		return nil, fmt.Errorf("%w: unknown default provider %q", kycerrors.ErrInvalidConfig, cfg.Default)
+	}
+	for _, r := range cfg.Routes {
+		if _, ok := cfg.Providers[r.Provider]; !ok {
+			return nil, fmt.Errorf("%w: route to unknown provider %q", kycerrors.ErrInvalidConfig, r.Provider)
+		}
+	}
+	for _, name := range cfg.Fallbacks {
+		if _, ok := cfg.Providers[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown fallback provider %q", kycerrors.ErrInvalidConfig, name)
+		}
+	}
+
+	names := make([]string, 0, len(cfg.Providers))
+	for name := range cfg.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	owners := cfg.Owners
+	if owners == nil {
+		owners = NewMemoryOwnerStore()
+	}
+
+	return &Router{
+		providers: cfg.Providers,
+		names:     names,
+		routes:    cfg.Routes,
+		def:       cfg.Default,
+		fallbacks: cfg.Fallbacks,
+		owners:    owners,
+	}, nil
+}
+
+func (r *Router) CreateApplicant(ctx context.Context, userID string) (*model.ApplicantInfo, error) {
+	if r == nil {
+		return nil, errors.New("nil router")
+	}
+
+	name := r.selectProvider(model.GenerateLinkRequest{UserID: userID})
+	info, err := r.providers[name].CreateApplicant(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Provider = name
+	r.remember(name, info.ApplicantID, userID)
+	return info, nil
+}
+
+// GetApplicant 从归属记录中查找 applicant 所属的 Provider；找不到归属时使用 Default。
+func (r *Router) GetApplicant(ctx context.Context, applicantID string) (*model.ApplicantInfo, error) {
+	if r == nil {
+		return nil, errors.New("nil router")
+	}
+
+	name, ok := r.owners.Owner(applicantKey(applicantID))
+	if !ok {
+		name = r.def
+	}
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("applicant %s owned by unknown provider %q", applicantID, name)
+	}
+
+	info, err := p.GetApplicant(ctx, applicantID)
+	if err != nil {
+		return nil, err
+	}
+	info.Provider = name
+	return info, nil
+}
+
+func (r *Router) GenerateLink(ctx context.Context, req model.GenerateLinkRequest) (string, error) {
+	if r == nil {
+		return "", errors.New("nil router")
+	}
+
+	var lastErr error
+	for _, name := range r.candidates(r.selectProvider(req)) {
+		url, err := r.providers[name].GenerateLink(ctx, req)
+		if err == nil {
+			// 已有归属的用户临时切到 Fallback 时不改写归属，主 Provider 恢复后仍回到它。
+			r.rememberIfAbsent(name, "", req.UserID)
+			return url, nil
+		}
+
+		lastErr = err
+		if !shouldFailover(ctx, err) {
+			return "", err
+		}
+	}
+	return "", lastErr
+}
+
+// VerifyAndParseWebhook 按回调携带的签名 header（见 WebhookSignatureProvider）选出候选 Provider 验签，
+// 第一个验签并解析成功的 Provider 视为回调来源。未实现 WebhookSignatureProvider 的 Provider 不参与分发；
+// 没有任何候选时返回 kycerrors.ErrMissingSignature。
+//
+// 回调只为尚无归属的 applicant / 用户记录归属，不会覆盖已有归属。
+func (r *Router) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	return r.VerifyAndParseWebhookContext(context.Background(), headers, rawBody)
+}
+
+// VerifyAndParseWebhookContext 与 VerifyAndParseWebhook 相同，ctx 传给实现了 WebhookContextProvider 的 Provider。
+func (r *Router) VerifyAndParseWebhookContext(ctx context.Context, headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	if r == nil {
+		return nil, errors.New("nil router")
+	}
+
+	var errs []error
+	for _, name := range r.names {
+		sp, ok := r.providers[name].(WebhookSignatureProvider)
+		if !ok {
+			continue
+		}
+		if h := sp.WebhookSignatureHeader(); h == "" || headers.Get(h) == "" {
+			continue
+		}
+
+		payload, err := verifyWebhook(ctx, r.providers[name], headers, rawBody)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+
+		r.rememberIfAbsent(name, payload.ApplicantID, payload.ExternalUserID)
+		return payload, nil
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("%w: no provider signature header in webhook", kycerrors.ErrMissingSignature)
+	}
+	return nil, fmt.Errorf("no provider accepted webhook: %w", errors.Join(errs...))
+}
+
+// selectProvider 为请求选择 Provider：已有归属的用户固定使用原 Provider，否则按规则匹配。
+func (r *Router) selectProvider(req model.GenerateLinkRequest) string {
+	if req.UserID != "" {
+		if name, ok := r.owners.Owner(userKey(req.UserID)); ok {
+			if _, exists := r.providers[name]; exists {
+				return name
+			}
+		}
+	}
+
+	for _, route := range r.routes {
+		if route.matches(req) {
+			return route.Provider
+		}
+	}
+	return r.def
+}
+
+// candidates 返回 GenerateLink 依次尝试的 Provider（首选 + 去重后的 Fallbacks）。
+func (r *Router) candidates(primary string) []string {
+	out := []string{primary}
+	for _, name := range r.fallbacks {
+		if name != primary {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func (r *Router) remember(provider, applicantID, userID string) {
+	if applicantID != "" {
+		r.owners.SetOwner(applicantKey(applicantID), provider)
+	}
+	if userID != "" {
+		r.owners.SetOwner(userKey(userID), provider)
+	}
+}
+
+// rememberIfAbsent 只记录尚无归属的 key，避免回调或故障转移改写已有用户的路由。
+func (r *Router) rememberIfAbsent(provider, applicantID, userID string) {
+	if applicantID != "" {
+		if _, ok := r.owners.Owner(applicantKey(applicantID)); !ok {
+			r.owners.SetOwner(applicantKey(applicantID), provider)
+		}
+	}
+	if userID != "" {
+		if _, ok := r.owners.Owner(userKey(userID)); !ok {
+			r.owners.SetOwner(userKey(userID), provider)
+		}
+	}
+}
+
+func (rt Route) matches(req model.GenerateLinkRequest) bool {
+	if len(rt.Countries) > 0 && !containsFold(rt.Countries, req.Country) {
+		return false
+	}
+	if len(rt.Levels) > 0 && !containsFold(rt.Levels, req.LevelName) {
+		return false
+	}
+	if len(rt.Segments) > 0 && !containsFold(rt.Segments, req.Segment) {
+		return false
+	}
+	if rt.Percent > 0 && bucket(req.UserID) >= rt.Percent {
+		return false
+	}
+	return true
+}
+
+func containsFold(list []string, v string) bool {
+	if v == "" {
+		return false
+	}
+	for _, s := range list {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// bucket 把 userID 稳定地映射到 0-99。
+func bucket(userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(userID))
+	return int(h.Sum32() % 100)
+}
+
+// shouldFailover 判断 GenerateLink 的错误是否值得换一个 Provider 重试：
+// Provider 5xx、单次请求超时或熔断器打开；调用方 ctx 已取消 / 超时时不再重试。
+func shouldFailover(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, kycerrors.ErrServerInternal) || errors.Is(err, kycerrors.ErrProviderUnavailable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+func applicantKey(applicantID string) string { return "applicant:" + applicantID }
+
+func userKey(userID string) string { return "user:" + userID }
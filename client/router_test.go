@@ -0,0 +1,314 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/kyctest/fakesumsub"
+	"github.com/dq/kyc-sdk/model"
+)
+
+// stubProvider 记录调用次数，并按 header 判断回调是否属于自己。
+type stubProvider struct {
+	name    string
+	linkErr error
+	links   int
+	gets    int
+}
+
+func (s *stubProvider) CreateApplicant(ctx context.Context, userID string) (*model.ApplicantInfo, error) {
+	return &model.ApplicantInfo{UserID: userID, ApplicantID: s.name + "-" + userID}, nil
+}
+
+func (s *stubProvider) GetApplicant(ctx context.Context, applicantID string) (*model.ApplicantInfo, error) {
+	s.gets++
+	return &model.ApplicantInfo{ApplicantID: applicantID}, nil
+}
+
+func (s *stubProvider) GenerateLink(ctx context.Context, req model.GenerateLinkRequest) (string, error) {
+	s.links++
+	if s.linkErr != nil {
+		return "", s.linkErr
+	}
+	return "https://" + s.name + "/" + req.UserID, nil
+}
+
+func (s *stubProvider) WebhookSignatureHeader() string { return "X-Stub-" + s.name }
+
+func (s *stubProvider) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	if headers.Get("X-Stub-"+s.name) != "valid" {
+		return nil, kycerrors.ErrInvalidSignature
+	}
+	return &model.WebhookPayload{ApplicantID: string(rawBody), ExternalUserID: "user-wh"}, nil
+}
+
+func TestRouter_RoutesByCountryAndLevel(t *testing.T) {
+	sumsub, veriff := &stubProvider{name: "sumsub"}, &stubProvider{name: "veriff"}
+	r, err := NewRouter(RouterConfig{
+		Providers: map[string]Provider{"sumsub": sumsub, "veriff": veriff},
+		Routes: []Route{
+			{Provider: "veriff", Countries: []string{"BRA", "MEX"}},
+			{Provider: "veriff", Levels: []string{"veriff-level"}},
+		},
+		Default: "sumsub",
+	})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	cases := []struct {
+		req  model.GenerateLinkRequest
+		want string
+	}{
+		{model.GenerateLinkRequest{UserID: "u1", Country: "bra"}, "https://veriff/u1"},
+		{model.GenerateLinkRequest{UserID: "u2", Country: "SGP"}, "https://sumsub/u2"},
+		{model.GenerateLinkRequest{UserID: "u3", LevelName: "veriff-level"}, "https://veriff/u3"},
+		// u2 已归属 sumsub，即使换了国家也保持不变。
+		{model.GenerateLinkRequest{UserID: "u2", Country: "MEX"}, "https://sumsub/u2"},
+	}
+	for _, tc := range cases {
+		url, err := r.GenerateLink(context.Background(), tc.req)
+		if err != nil {
+			t.Fatalf("GenerateLink(%+v): %v", tc.req, err)
+		}
+		if url != tc.want {
+			t.Fatalf("GenerateLink(%+v) = %s, want %s", tc.req, url, tc.want)
+		}
+	}
+}
+
+func TestRouter_PercentSplitIsStable(t *testing.T) {
+	a, b := &stubProvider{name: "a"}, &stubProvider{name: "b"}
+	r, err := NewRouter(RouterConfig{
+		Providers: map[string]Provider{"a": a, "b": b},
+		Routes:    []Route{{Provider: "b", Percent: 30}},
+		Default:   "a",
+		Owners:    nopOwnerStore{},
+	})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		userID := "user-" + strconv.Itoa(i)
+		if _, err := r.GenerateLink(context.Background(), model.GenerateLinkRequest{UserID: userID}); err != nil {
+			t.Fatalf("GenerateLink: %v", err)
+		}
+	}
+	if b.links < 200 || b.links > 400 {
+		t.Fatalf("expected roughly 30%% to b, got %d/1000", b.links)
+	}
+
+	first, _ := r.GenerateLink(context.Background(), model.GenerateLinkRequest{UserID: "same-user"})
+	second, _ := r.GenerateLink(context.Background(), model.GenerateLinkRequest{UserID: "same-user"})
+	if first != second {
+		t.Fatalf("expected stable assignment, got %s and %s", first, second)
+	}
+}
+
+func TestRouter_GenerateLinkFailover(t *testing.T) {
+	primary := &stubProvider{name: "primary", linkErr: &kycerrors.HTTPError{StatusCode: http.StatusBadGateway}}
+	backup := &stubProvider{name: "backup"}
+	r, err := NewRouter(RouterConfig{
+		Providers: map[string]Provider{"primary": primary, "backup": backup},
+		Default:   "primary",
+		Fallbacks: []string{"backup"},
+	})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	url, err := r.GenerateLink(context.Background(), model.GenerateLinkRequest{UserID: "u1"})
+	if err != nil {
+		t.Fatalf("GenerateLink: %v", err)
+	}
+	if url != "https://backup/u1" {
+		t.Fatalf("expected failover to backup, got %s", url)
+	}
+
+	// 熔断打开时直接切换。
+	primary.linkErr = fmt.Errorf("%w: primary circuit open", kycerrors.ErrProviderUnavailable)
+	if url, err := r.GenerateLink(context.Background(), model.GenerateLinkRequest{UserID: "u3"}); err != nil || url != "https://backup/u3" {
+		t.Fatalf("expected failover on open circuit, got %s, %v", url, err)
+	}
+
+	// 4xx 不触发故障转移。
+	primary.linkErr = &kycerrors.HTTPError{StatusCode: http.StatusBadRequest}
+	if _, err := r.GenerateLink(context.Background(), model.GenerateLinkRequest{UserID: "u2"}); !errors.Is(err, kycerrors.ErrBadRequest) {
+		t.Fatalf("expected ErrBadRequest, got: %v", err)
+	}
+}
+
+func TestRouter_FailoverDoesNotMoveOwnedUser(t *testing.T) {
+	primary, backup := &stubProvider{name: "primary"}, &stubProvider{name: "backup"}
+	r, err := NewRouter(RouterConfig{
+		Providers: map[string]Provider{"primary": primary, "backup": backup},
+		Default:   "primary",
+		Fallbacks: []string{"backup"},
+	})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	info, err := r.CreateApplicant(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+
+	// 主 Provider 失败一次，本次链接由 backup 生成。
+	primary.linkErr = &kycerrors.HTTPError{StatusCode: http.StatusServiceUnavailable}
+	if url, err := r.GenerateLink(context.Background(), model.GenerateLinkRequest{UserID: "u1"}); err != nil || url != "https://backup/u1" {
+		t.Fatalf("expected failover to backup, got %s, %v", url, err)
+	}
+
+	// 恢复后用户仍归属 primary。
+	primary.linkErr = nil
+	if url, err := r.GenerateLink(context.Background(), model.GenerateLinkRequest{UserID: "u1"}); err != nil || url != "https://primary/u1" {
+		t.Fatalf("expected user to return to primary, got %s, %v", url, err)
+	}
+	if got, err := r.GetApplicant(context.Background(), info.ApplicantID); err != nil || got.Provider != "primary" {
+		t.Fatalf("expected applicant served by primary, got %+v, %v", got, err)
+	}
+	if backup.gets != 0 {
+		t.Fatalf("backup must not serve the primary's applicant")
+	}
+}
+
+func TestRouter_OwnershipForGetAndWebhook(t *testing.T) {
+	sumsub, veriff := &stubProvider{name: "sumsub"}, &stubProvider{name: "veriff"}
+	r, err := NewRouter(RouterConfig{
+		Providers: map[string]Provider{"sumsub": sumsub, "veriff": veriff},
+		Routes:    []Route{{Provider: "veriff", Segments: []string{"vip"}}},
+		Default:   "sumsub",
+	})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	if _, err := r.GenerateLink(context.Background(), model.GenerateLinkRequest{UserID: "u1", Segment: "vip"}); err != nil {
+		t.Fatalf("GenerateLink: %v", err)
+	}
+	info, err := r.CreateApplicant(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	if info.Provider != "veriff" {
+		t.Fatalf("expected sticky veriff, got %s", info.Provider)
+	}
+
+	got, err := r.GetApplicant(context.Background(), info.ApplicantID)
+	if err != nil {
+		t.Fatalf("GetApplicant: %v", err)
+	}
+	if got.Provider != "veriff" || veriff.gets != 1 || sumsub.gets != 0 {
+		t.Fatalf("expected GetApplicant routed to veriff, got %+v", got)
+	}
+
+	h := http.Header{}
+	h.Set("X-Stub-sumsub", "valid")
+	payload, err := r.VerifyAndParseWebhook(h, []byte("ap-wh"))
+	if err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if payload.ApplicantID != "ap-wh" {
+		t.Fatalf("payload mismatch: %+v", payload)
+	}
+	if _, err := r.GetApplicant(context.Background(), "ap-wh"); err != nil || sumsub.gets != 1 {
+		t.Fatalf("expected webhook applicant to be owned by sumsub")
+	}
+
+	if _, err := r.VerifyAndParseWebhook(http.Header{}, []byte("x")); !errors.Is(err, kycerrors.ErrMissingSignature) {
+		t.Fatalf("expected ErrMissingSignature when no provider header is present, got: %v", err)
+	}
+}
+
+// openProvider 不验签，也不声明签名 header。
+type openProvider struct{ stubProvider }
+
+func (openProvider) WebhookSignatureHeader() string { return "" }
+
+func (openProvider) VerifyAndParseWebhook(http.Header, []byte) (*model.WebhookPayload, error) {
+	return &model.WebhookPayload{ApplicantID: "ap-x", ExternalUserID: "victim"}, nil
+}
+
+func TestRouter_WebhookDoesNotTakeOverOwners(t *testing.T) {
+	sumsub := &stubProvider{name: "sumsub"}
+	r, err := NewRouter(RouterConfig{
+		Providers: map[string]Provider{"sumsub": sumsub, "a-open": &openProvider{}},
+		Default:   "sumsub",
+	})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+	if _, err := r.GenerateLink(context.Background(), model.GenerateLinkRequest{UserID: "victim"}); err != nil {
+		t.Fatalf("GenerateLink: %v", err)
+	}
+
+	// 未声明签名 header 的 Provider 不参与分发。
+	if _, err := r.VerifyAndParseWebhook(http.Header{"X-Anything": {"1"}}, []byte("{}")); !errors.Is(err, kycerrors.ErrMissingSignature) {
+		t.Fatalf("expected ErrMissingSignature, got: %v", err)
+	}
+
+	// 签名错误的回调被拒绝；合法回调不改写已有归属。
+	if _, err := r.VerifyAndParseWebhook(http.Header{"X-Stub-Sumsub": {"forged"}}, []byte("ap-1")); !errors.Is(err, kycerrors.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got: %v", err)
+	}
+	r.owners.SetOwner(applicantKey("ap-owned"), "a-open")
+	if _, err := r.VerifyAndParseWebhook(http.Header{"X-Stub-Sumsub": {"valid"}}, []byte("ap-owned")); err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if owner, _ := r.owners.Owner(applicantKey("ap-owned")); owner != "a-open" {
+		t.Fatalf("expected existing owner to be kept, got %q", owner)
+	}
+}
+
+type nopOwnerStore struct{}
+
+func (nopOwnerStore) Owner(key string) (string, bool) { return "", false }
+
+func (nopOwnerStore) SetOwner(key, provider string) {}
+
+func TestClient_AsProvider_CallOptionsReachInnerClient(t *testing.T) {
+	srv := fakesumsub.New(fakesumsub.Options{})
+	defer srv.Close()
+
+	var sent []string
+	cfg := srv.Config()
+	cfg.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		sent = append(sent, req.Method+" "+req.Header.Get("X-Request-Id"))
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	inner, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	router, err := NewRouter(RouterConfig{
+		Providers: map[string]Provider{"sumsub": inner.AsProvider()},
+		Default:   "sumsub",
+	})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+	outer, err := New(router)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// 外层 Client 的 WithRequestID 经 Router 与 AsProvider 传到内层 Client 发出的 HTTP 请求。
+	if _, err := outer.CreateApplicant(context.Background(), "user-1", WithRequestID("req-outer")); err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	_, err = outer.GetApplicant(context.Background(), "missing", WithRequestID("req-missing"))
+	var httpErr *kycerrors.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.RequestID != "req-missing" {
+		t.Fatalf("expected HTTPError with outer request id, got: %v", err)
+	}
+	if len(sent) != 2 || sent[0] != "POST req-outer" || sent[1] != "GET req-missing" {
+		t.Fatalf("unexpected requests: %v", sent)
+	}
+}
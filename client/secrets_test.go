@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/kyctest/fakesumsub"
+)
+
+func TestSecrets_RotationWithoutRestart(t *testing.T) {
+	srv := fakesumsub.New(fakesumsub.Options{})
+	defer srv.Close()
+
+	var mu sync.Mutex
+	secrets := map[string]string{
+		config.SecretAppToken:      fakesumsub.DefaultAppToken,
+		config.SecretSecretKey:     "stale-key",
+		config.SecretWebhookSecret: fakesumsub.DefaultWebhookSecret,
+	}
+	cache := config.CacheSecrets(config.SecretFunc(func(_ context.Context, name string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return secrets[name], nil
+	}), 0)
+
+	cfg := &config.Config{BaseURL: srv.URL, Secrets: cache}
+	cli, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if _, err := cli.CreateApplicant(context.Background(), "user-1"); !errors.Is(err, kycerrors.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized with stale key, got: %v", err)
+	}
+
+	mu.Lock()
+	secrets[config.SecretSecretKey] = fakesumsub.DefaultSecretKey
+	mu.Unlock()
+	cache.Invalidate()
+
+	if _, err := cli.CreateApplicant(context.Background(), "user-1"); err != nil {
+		t.Fatalf("CreateApplicant after rotation: %v", err)
+	}
+
+	body := []byte(`{"type":"applicantPending","applicantId":"a1"}`)
+	mac := hmac.New(sha256.New, []byte(fakesumsub.DefaultWebhookSecret))
+	mac.Write(body)
+	if _, err := cli.VerifyAndParseWebhook(http.Header{"X-Payload-Digest": {hex.EncodeToString(mac.Sum(nil))}}, body); err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+}
+
+func TestSecrets_WebhookSecretUsesRequestContext(t *testing.T) {
+	cfg := &config.Config{
+		BaseURL: "https://api.sumsub.com",
+		Secrets: config.SecretFunc(func(ctx context.Context, name string) (string, error) {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			return "hook-" + name, nil
+		}),
+	}
+	cli, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	body := []byte(`{"type":"applicantPending","applicantId":"a1"}`)
+	mac := hmac.New(sha256.New, []byte("hook-"+config.SecretWebhookSecret))
+	mac.Write(body)
+	headers := http.Header{"X-Payload-Digest": {hex.EncodeToString(mac.Sum(nil))}}
+	// 请求已取消时，读取密钥应感知到调用方的 ctx，而不是 context.Background()。
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cli.VerifyAndParseWebhookContext(ctx, headers, body); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled from secret source, got: %v", err)
+	}
+	if _, err := cli.VerifyAndParseWebhookContext(context.Background(), headers, body); err != nil {
+		t.Fatalf("VerifyAndParseWebhookContext: %v", err)
+	}
+}
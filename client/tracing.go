@@ -0,0 +1,40 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/dq/kyc-sdk/model"
+)
+
+const instrumentationName = "github.com/dq/kyc-sdk/client"
+
+// WithTracerProvider 开启 OpenTelemetry 追踪：每个 Client 方法一个 span，
+// 每次 Provider HTTP 尝试一个子 span，并通过 W3C traceparent header 向 Provider 传播 trace context。
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracerProvider = tp
+	}
+}
+
+// applicantAttr 返回 applicant ID 的哈希（sha256 前 16 位），避免在 trace 中暴露原始 ID。
+func applicantAttr(applicantID string) attribute.KeyValue {
+	sum := sha256.Sum256([]byte(applicantID))
+	return attribute.String("kyc.applicant_id_hash", hex.EncodeToString(sum[:8]))
+}
+
+func levelAttr(level string) attribute.KeyValue {
+	return attribute.String("kyc.level", level)
+}
+
+func resultAttr(result model.KycResult) attribute.KeyValue {
+	return attribute.String("kyc.result", string(result))
+}
+
+func noopTracer() trace.Tracer {
+	return noop.NewTracerProvider().Tracer(instrumentationName)
+}
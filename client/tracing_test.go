@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kyctest/fakesumsub"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func spanAttr(s tracetest.SpanStub, key string) (attribute.Value, bool) {
+	for _, kv := range s.Attributes {
+		if string(kv.Key) == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestWithTracerProvider_SpansAndPropagation(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	srv := fakesumsub.New(fakesumsub.Options{})
+	defer srv.Close()
+
+	var traceparents []string
+	cfg := srv.Config()
+	cfg.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		traceparents = append(traceparents, req.Header.Get("traceparent"))
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	cli, err := NewClient(cfg, WithTracerProvider(tp))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, root := tp.Tracer("test").Start(context.Background(), "onboarding")
+	info, err := cli.CreateApplicant(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	root.End()
+
+	spans := exporter.GetSpans()
+	var op, httpSpan tracetest.SpanStub
+	for _, s := range spans {
+		switch s.Name {
+		case "kyc.CreateApplicant":
+			op = s
+		case "HTTP POST":
+			httpSpan = s
+		}
+	}
+	if op.Name == "" || httpSpan.Name == "" {
+		t.Fatalf("missing spans: %+v", spans)
+	}
+	if op.Parent.SpanID() != root.SpanContext().SpanID() || httpSpan.Parent.SpanID() != op.SpanContext.SpanID() {
+		t.Fatalf("unexpected span hierarchy")
+	}
+
+	if v, _ := spanAttr(op, "kyc.provider"); v.AsString() != "sumsub" {
+		t.Fatalf("kyc.provider = %q", v.AsString())
+	}
+	if v, _ := spanAttr(op, "kyc.operation"); v.AsString() != "CreateApplicant" {
+		t.Fatalf("kyc.operation = %q", v.AsString())
+	}
+	if v, ok := spanAttr(op, "kyc.applicant_id_hash"); !ok || v.AsString() == info.ApplicantID {
+		t.Fatalf("applicant id should be hashed, got %q", v.AsString())
+	}
+	if v, _ := spanAttr(httpSpan, "http.response.status_code"); v.AsInt64() != http.StatusCreated {
+		t.Fatalf("status code = %d", v.AsInt64())
+	}
+
+	if len(traceparents) != 1 || !strings.Contains(traceparents[0], root.SpanContext().TraceID().String()) {
+		t.Fatalf("expected W3C traceparent with trace id, got %v", traceparents)
+	}
+}
+
+func TestWithTracerProvider_WebhookSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	cli, err := New(basicProvider{}, WithTracerProvider(tp))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := cli.VerifyAndParseWebhookContext(context.Background(), http.Header{}, []byte(`{}`)); err != nil {
+		t.Fatalf("VerifyAndParseWebhookContext: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "kyc.VerifyAndParseWebhook" || spans[0].Status.Code == codes.Error {
+		t.Fatalf("unexpected spans: %+v", spans)
+	}
+}
+
+func TestMultiTenant_WebhookDispatchSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	srv := fakesumsub.New(fakesumsub.Options{WebhookSecret: "hook-a"})
+	defer srv.Close()
+	mt, err := NewMultiTenant(MultiTenantConfig{
+		Tenants:           map[string]*config.Config{"a": srv.Config()},
+		WebhookPathPrefix: "/webhooks/",
+	}, WithTracerProvider(tp))
+	if err != nil {
+		t.Fatalf("NewMultiTenant: %v", err)
+	}
+
+	var handlerSpan trace.SpanContext
+	h := mt.WebhookHandler(func(ctx context.Context, _ string, _ *WebhookPayload) error {
+		handlerSpan = trace.SpanContextFromContext(ctx)
+		return errors.New("downstream failed")
+	})
+	ctx, root := tp.Tracer("test").Start(context.Background(), "POST /webhooks")
+	req := signedWebhook(t, "/webhooks/a", "hook-a", []byte(`{"type":"applicantPending","applicantId":"a1"}`)).WithContext(ctx)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	root.End()
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status %d, want 500", w.Code)
+	}
+
+	var dispatch tracetest.SpanStub
+	for _, s := range exporter.GetSpans() {
+		if s.Name == "kyc.DispatchWebhook" {
+			dispatch = s
+		}
+	}
+	if dispatch.Name == "" || dispatch.Parent.SpanID() != root.SpanContext().SpanID() {
+		t.Fatalf("expected kyc.DispatchWebhook under the request span: %+v", exporter.GetSpans())
+	}
+	if handlerSpan.SpanID() != dispatch.SpanContext.SpanID() {
+		t.Fatalf("handle should run inside the dispatch span")
+	}
+	if v, _ := spanAttr(dispatch, "kyc.tenant"); v.AsString() != "a" {
+		t.Fatalf("kyc.tenant = %q", v.AsString())
+	}
+	if v, _ := spanAttr(dispatch, "kyc.webhook_type"); v.AsString() != "applicantPending" {
+		t.Fatalf("kyc.webhook_type = %q", v.AsString())
+	}
+	if dispatch.Status.Code != codes.Error {
+		t.Fatalf("handler error should mark the span, got %v", dispatch.Status)
+	}
+}
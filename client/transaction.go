@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+type Transaction = model.Transaction
+
+// TransactionProvider 是支持交易监控（KYT）的 Provider 需要额外实现的接口。
+type TransactionProvider interface {
+	SubmitTransaction(ctx context.Context, applicantID string, tx model.Transaction) (*model.TransactionReview, error)
+	GetTransactionReview(ctx context.Context, transactionID string) (*model.TransactionReview, error)
+}
+
+// SubmitTransaction 提交一笔交易到交易监控。审核结论可能是异步的，
+// 最终结果通过 GetTransactionReview 查询或 applicantKytTxnApproved / applicantKytOnHold Webhook 获知。
+func (c *Client) SubmitTransaction(ctx context.Context, applicantID string, tx Transaction, opts ...CallOption) (_ *model.TransactionReview, err error) {
+	p, err := c.transactionProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "SubmitTransaction", opts, applicantAttr(applicantID))
+	defer func() { call.end(err) }()
+
+	review, err := p.SubmitTransaction(ctx, applicantID, tx)
+	if err != nil {
+		return nil, err
+	}
+	call.span.SetAttributes(resultAttr(review.Result))
+	return review, nil
+}
+
+// GetTransactionReview 按 Provider 侧交易 ID（TransactionReview.ID）查询审核结果。
+func (c *Client) GetTransactionReview(ctx context.Context, transactionID string, opts ...CallOption) (_ *model.TransactionReview, err error) {
+	p, err := c.transactionProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "GetTransactionReview", opts)
+	defer func() { call.end(err) }()
+
+	review, err := p.GetTransactionReview(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	call.span.SetAttributes(resultAttr(review.Result))
+	return review, nil
+}
+
+func (c *Client) transactionProvider() (TransactionProvider, error) {
+	if c == nil || c.provider == nil {
+		return nil, errors.New("nil client")
+	}
+	p, ok := c.provider.(TransactionProvider)
+	if !ok {
+		return nil, kycerrors.ErrNotSupported
+	}
+	return p, nil
+}
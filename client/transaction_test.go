@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func TestClient_SubmitTransaction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/applicants/a1/kyt/txns/-/data" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got["txnId"] != "tx-1" || got["txnDate"] != "2026-01-02 03:04:05+0000" {
+			t.Fatalf("txn mismatch: %v", got)
+		}
+		info, _ := got["info"].(map[string]any)
+		if info["direction"] != "out" || info["amount"] != float64(150.5) || info["currencyCode"] != "USD" {
+			t.Fatalf("info mismatch: %v", info)
+		}
+		cp, ok := got["counterparty"].(map[string]any)
+		if !ok || cp["fullName"] != "Bob" {
+			t.Fatalf("counterparty mismatch: %v", got["counterparty"])
+		}
+		applicant, _ := got["applicant"].(map[string]any)
+		device, _ := applicant["device"].(map[string]any)
+		ip, _ := device["ipInfo"].(map[string]any)
+		if ip["ip"] != "1.2.3.4" {
+			t.Fatalf("device mismatch: %v", applicant)
+		}
+
+		_, _ = w.Write([]byte(`{"id":"stx1","applicantId":"a1","data":{"txnId":"tx-1"},"score":12.5,"review":{"reviewStatus":"onHold","reviewResult":{"reviewAnswer":"YELLOW"}}}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	review, err := cli.SubmitTransaction(context.Background(), "a1", Transaction{
+		ID:         "tx-1",
+		Amount:     150.5,
+		Currency:   "USD",
+		Direction:  model.TransactionOut,
+		OccurredAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Counterparty: model.Counterparty{
+			FullName: "Bob",
+			Type:     "individual",
+		},
+		PaymentMethod: model.PaymentMethod{Type: "card", AccountID: "4111****1111"},
+		Device:        model.DeviceInfo{IPAddress: "1.2.3.4"},
+	})
+	if err != nil {
+		t.Fatalf("SubmitTransaction: %v", err)
+	}
+	if review.ID != "stx1" || review.TransactionID != "tx-1" || review.Result != model.ResultYellow || review.Score != 12.5 {
+		t.Fatalf("review mismatch: %+v", review)
+	}
+}
+
+func TestClient_GetTransactionReview(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/kyt/txns/stx1/one" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"stx1","applicantId":"a1","data":{"txnId":"tx-1"},"review":{"reviewStatus":"completed","reviewResult":{"reviewAnswer":"GREEN"}}}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	review, err := cli.GetTransactionReview(context.Background(), "stx1")
+	if err != nil {
+		t.Fatalf("GetTransactionReview: %v", err)
+	}
+	if review.Status != model.StatusReviewed || review.Result != model.ResultGreen {
+		t.Fatalf("review mismatch: %+v", review)
+	}
+}
+
+func TestClient_VerifyAndParseWebhook_KytOnHold(t *testing.T) {
+	raw := []byte(`{"type":"applicantKytOnHold","applicantId":"a1","externalUserId":"u1","kytTxnId":"stx1","kytDataTxnId":"tx-1","reviewStatus":"onHold"}`)
+	secret := "secret"
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(raw)
+
+	cli, err := NewClient(&config.Config{BaseURL: "https://example.com", AppToken: "app", SecretKey: "app-secret", WebhookSecret: secret})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	h := http.Header{}
+	h.Set("X-Payload-Digest", hex.EncodeToString(mac.Sum(nil)))
+	payload, err := cli.VerifyAndParseWebhook(h, raw)
+	if err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if payload.EventType() != model.EventApplicantKytOnHold || payload.TransactionID != "tx-1" {
+		t.Fatalf("payload mismatch: %+v", payload)
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+type TravelRuleTransfer = model.TravelRuleTransfer
+
+// TravelRuleProvider 是支持加密货币 Travel Rule 数据交换的 Provider 需要额外实现的接口。
+type TravelRuleProvider interface {
+	SubmitTravelRuleTransfer(ctx context.Context, applicantID string, transfer model.TravelRuleTransfer) (*model.TravelRuleInfo, error)
+	GetTravelRuleTransfer(ctx context.Context, transferID string) (*model.TravelRuleInfo, error)
+	ConfirmTravelRuleTransfer(ctx context.Context, transferID string) (*model.TravelRuleInfo, error)
+	RejectTravelRuleTransfer(ctx context.Context, transferID, reason string) (*model.TravelRuleInfo, error)
+}
+
+// SubmitTravelRuleTransfer 提交一笔转账的发起方 / 受益方 VASP 与钱包信息。
+func (c *Client) SubmitTravelRuleTransfer(ctx context.Context, applicantID string, transfer TravelRuleTransfer, opts ...CallOption) (_ *model.TravelRuleInfo, err error) {
+	p, err := c.travelRuleProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "SubmitTravelRuleTransfer", opts, applicantAttr(applicantID))
+	defer func() { call.end(err) }()
+
+	info, err := p.SubmitTravelRuleTransfer(ctx, applicantID, transfer)
+	if err != nil {
+		return nil, err
+	}
+	call.span.SetAttributes(resultAttr(info.Result))
+	return info, nil
+}
+
+// GetTravelRuleTransfer 按 Provider 侧转账 ID（TravelRuleInfo.ID）查询状态。
+func (c *Client) GetTravelRuleTransfer(ctx context.Context, transferID string, opts ...CallOption) (_ *model.TravelRuleInfo, err error) {
+	p, err := c.travelRuleProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "GetTravelRuleTransfer", opts)
+	defer func() { call.end(err) }()
+
+	info, err := p.GetTravelRuleTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	call.span.SetAttributes(resultAttr(info.Result))
+	return info, nil
+}
+
+// ConfirmTravelRuleTransfer 确认一笔对方 VASP 发来的转入转账。
+func (c *Client) ConfirmTravelRuleTransfer(ctx context.Context, transferID string, opts ...CallOption) (_ *model.TravelRuleInfo, err error) {
+	p, err := c.travelRuleProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "ConfirmTravelRuleTransfer", opts)
+	defer func() { call.end(err) }()
+
+	info, err := p.ConfirmTravelRuleTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	call.span.SetAttributes(resultAttr(info.Result))
+	return info, nil
+}
+
+// RejectTravelRuleTransfer 拒绝一笔对方 VASP 发来的转入转账。
+func (c *Client) RejectTravelRuleTransfer(ctx context.Context, transferID, reason string, opts ...CallOption) (_ *model.TravelRuleInfo, err error) {
+	p, err := c.travelRuleProvider()
+	if err != nil {
+		return nil, err
+	}
+	ctx, call := c.begin(ctx, "RejectTravelRuleTransfer", opts)
+	defer func() { call.end(err) }()
+
+	info, err := p.RejectTravelRuleTransfer(ctx, transferID, reason)
+	if err != nil {
+		return nil, err
+	}
+	call.span.SetAttributes(resultAttr(info.Result))
+	return info, nil
+}
+
+func (c *Client) travelRuleProvider() (TravelRuleProvider, error) {
+	if c == nil || c.provider == nil {
+		return nil, errors.New("nil client")
+	}
+	p, ok := c.provider.(TravelRuleProvider)
+	if !ok {
+		return nil, kycerrors.ErrNotSupported
+	}
+	return p, nil
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func TestClient_SubmitTravelRuleTransfer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/applicants/a1/kyt/txns/-/data" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got["type"] != "travelRule" {
+			t.Fatalf("type mismatch: %v", got["type"])
+		}
+		info, _ := got["info"].(map[string]any)
+		if info["direction"] != "out" || info["currencyCode"] != "USDT" {
+			t.Fatalf("info mismatch: %v", info)
+		}
+		crypto, _ := info["cryptoParams"].(map[string]any)
+		if crypto["cryptoChain"] != "TRX" {
+			t.Fatalf("cryptoParams mismatch: %v", crypto)
+		}
+
+		applicant, _ := got["applicant"].(map[string]any)
+		if applicant["fullName"] != "Alice" {
+			t.Fatalf("applicant should be originator: %v", applicant)
+		}
+		cp, _ := got["counterparty"].(map[string]any)
+		pm, _ := cp["paymentMethod"].(map[string]any)
+		if pm["accountId"] != "T-wallet-bob" {
+			t.Fatalf("counterparty wallet mismatch: %v", cp)
+		}
+		inst, _ := cp["institutionInfo"].(map[string]any)
+		if inst["name"] != "Other VASP" {
+			t.Fatalf("counterparty VASP mismatch: %v", cp)
+		}
+
+		_, _ = w.Write([]byte(`{"id":"stx1","applicantId":"a1","data":{"txnId":"wd-1"},"travelRuleInfo":{"status":"awaitingCounterparty"}}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	info, err := cli.SubmitTravelRuleTransfer(context.Background(), "a1", TravelRuleTransfer{
+		ID:        "wd-1",
+		Direction: model.TransactionOut,
+		Amount:    100,
+		Asset:     "USDT",
+		Network:   "TRX",
+		Originator: model.TravelRuleParty{
+			FullName:      "Alice",
+			WalletAddress: "T-wallet-alice",
+			VASP:          model.VASP{Name: "Our VASP"},
+		},
+		Beneficiary: model.TravelRuleParty{
+			FullName:      "Bob",
+			WalletAddress: "T-wallet-bob",
+			VASP:          model.VASP{ID: "did:vasp:2", Name: "Other VASP"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SubmitTravelRuleTransfer: %v", err)
+	}
+	if info.ID != "stx1" || info.TransferID != "wd-1" || info.Status != model.TravelRuleAwaitingCounterparty {
+		t.Fatalf("info mismatch: %+v", info)
+	}
+}
+
+func TestClient_RejectTravelRuleTransfer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/kyt/txns/stx2/travelRule/reject" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got["reason"] != "unknown beneficiary" {
+			t.Fatalf("reason mismatch: %v", got["reason"])
+		}
+
+		_, _ = w.Write([]byte(`{"id":"stx2","data":{"txnId":"dep-1"},"travelRuleInfo":{"status":"rejected"}}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewClient(&config.Config{BaseURL: srv.URL, AppToken: "app", SecretKey: "secret", WebhookSecret: "hook"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	info, err := cli.RejectTravelRuleTransfer(context.Background(), "stx2", "unknown beneficiary")
+	if err != nil {
+		t.Fatalf("RejectTravelRuleTransfer: %v", err)
+	}
+	if info.Status != model.TravelRuleRejected {
+		t.Fatalf("status mismatch: %s", info.Status)
+	}
+}
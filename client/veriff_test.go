@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func veriffSig(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestNewVeriffClient_InvalidConfig(t *testing.T) {
+	if _, err := NewVeriffClient(&config.Config{Veriff: config.VeriffConfig{APIKey: "key"}}); !errors.Is(err, kycerrors.ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got: %v", err)
+	}
+}
+
+func TestVeriffClient_GenerateLink(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sessions" || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-AUTH-CLIENT") != "key" {
+			t.Fatalf("unexpected X-AUTH-CLIENT: %s", r.Header.Get("X-AUTH-CLIENT"))
+		}
+
+		var got struct {
+			Verification struct {
+				Callback   string `json:"callback"`
+				VendorData string `json:"vendorData"`
+			} `json:"verification"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got.Verification.VendorData != "user-1" || got.Verification.Callback != "https://ok" {
+			t.Fatalf("verification mismatch: %+v", got.Verification)
+		}
+
+		_, _ = w.Write([]byte(`{"status":"success","verification":{"id":"s-1","url":"https://veriff/link","vendorData":"user-1","status":"created"}}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewVeriffClient(&config.Config{
+		Veriff: config.VeriffConfig{BaseURL: srv.URL, APIKey: "key", SharedSecret: "secret", CallbackURL: "https://default"},
+	})
+	if err != nil {
+		t.Fatalf("NewVeriffClient: %v", err)
+	}
+
+	url, err := cli.GenerateLink(context.Background(), GenerateLinkRequest{UserID: "user-1", SuccessURL: "https://ok"})
+	if err != nil {
+		t.Fatalf("GenerateLink: %v", err)
+	}
+	if url != "https://veriff/link" {
+		t.Fatalf("url mismatch: %s", url)
+	}
+}
+
+func TestVeriffClient_GetApplicant(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sessions/s-1/decision" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("X-HMAC-SIGNATURE") != veriffSig("secret", []byte("s-1")) {
+			t.Fatalf("signature mismatch")
+		}
+		_, _ = w.Write([]byte(`{"status":"success","verification":{"id":"s-1","code":9102,"status":"declined","vendorData":"user-1"}}`))
+	}))
+	defer srv.Close()
+
+	cli, err := NewVeriffClient(&config.Config{Veriff: config.VeriffConfig{BaseURL: srv.URL, APIKey: "key", SharedSecret: "secret"}})
+	if err != nil {
+		t.Fatalf("NewVeriffClient: %v", err)
+	}
+
+	info, err := cli.GetApplicant(context.Background(), "s-1")
+	if err != nil {
+		t.Fatalf("GetApplicant: %v", err)
+	}
+	if info.UserID != "user-1" || info.Status != model.StatusReviewed || info.Result != model.ResultRed || info.Provider != "veriff" {
+		t.Fatalf("applicant mismatch: %+v", info)
+	}
+}
+
+func TestVeriffClient_VerifyAndParseWebhook(t *testing.T) {
+	cli, err := NewVeriffClient(&config.Config{Veriff: config.VeriffConfig{APIKey: "key", SharedSecret: "secret"}})
+	if err != nil {
+		t.Fatalf("NewVeriffClient: %v", err)
+	}
+
+	decision := []byte(`{"status":"success","verification":{"id":"s-1","code":9001,"status":"approved","vendorData":"user-1"}}`)
+	h := http.Header{}
+	h.Set("X-HMAC-SIGNATURE", veriffSig("secret", decision))
+	payload, err := cli.VerifyAndParseWebhook(h, decision)
+	if err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if payload.EventType() != model.EventApplicantReviewed || payload.ApplicantID != "s-1" || payload.ReviewResult != model.ResultGreen {
+		t.Fatalf("decision payload mismatch: %+v", payload)
+	}
+
+	event := []byte(`{"id":"s-1","attemptId":"at-1","feature":"selfid","code":7002,"action":"submitted","vendorData":"user-1"}`)
+	h.Set("X-HMAC-SIGNATURE", veriffSig("secret", event))
+	payload, err = cli.VerifyAndParseWebhook(h, event)
+	if err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if payload.EventType() != model.EventApplicantPending || payload.ExternalUserID != "user-1" {
+		t.Fatalf("event payload mismatch: %+v", payload)
+	}
+
+	h.Set("X-HMAC-SIGNATURE", veriffSig("other", event))
+	if _, err := cli.VerifyAndParseWebhook(h, event); err == nil {
+		t.Fatalf("expected error")
+	}
+}
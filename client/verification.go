@@ -3,8 +3,13 @@ package client
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"net/http"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dq/kyc-sdk/internal/httpclient"
 	"github.com/dq/kyc-sdk/model"
 )
 
@@ -12,16 +17,66 @@ type GenerateLinkRequest = model.GenerateLinkRequest
 
 type WebhookPayload = model.WebhookPayload
 
-func (c *Client) GenerateLink(ctx context.Context, req GenerateLinkRequest) (string, error) {
+func (c *Client) GenerateLink(ctx context.Context, req GenerateLinkRequest, opts ...CallOption) (_ string, err error) {
 	if c == nil || c.provider == nil {
 		return "", errors.New("nil client")
 	}
+	ctx, call := c.begin(ctx, "GenerateLink", opts, levelAttr(req.LevelName))
+	defer func() { call.end(err) }()
+
+	c.logger.DebugContext(ctx, "kyc-sdk: generate link", slog.String("provider", c.name), slog.Any("request", req))
 	return c.provider.GenerateLink(ctx, req)
 }
 
+// WebhookSignatureHeader 返回 Provider 的 Webhook 签名 header（见 WebhookSignatureProvider），
+// Provider 未声明时返回空。
+func (c *Client) WebhookSignatureHeader() string {
+	if c == nil {
+		return ""
+	}
+	if p, ok := c.provider.(WebhookSignatureProvider); ok {
+		return p.WebhookSignatureHeader()
+	}
+	return ""
+}
+
 func (c *Client) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*WebhookPayload, error) {
+	return c.VerifyAndParseWebhookContext(context.Background(), headers, rawBody)
+}
+
+// VerifyAndParseWebhookContext 与 VerifyAndParseWebhook 相同，span 与日志挂在 ctx（通常是 Webhook 请求的 context）下；
+// Provider 实现 WebhookContextProvider 时，ctx 也用于读取 Webhook 密钥。
+func (c *Client) VerifyAndParseWebhookContext(ctx context.Context, headers http.Header, rawBody []byte) (_ *WebhookPayload, err error) {
 	if c == nil || c.provider == nil {
 		return nil, errors.New("nil client")
 	}
-	return c.provider.VerifyAndParseWebhook(headers, rawBody)
+	attrs := []attribute.KeyValue{
+		attribute.String("kyc.provider", c.name),
+		attribute.String("kyc.operation", "VerifyAndParseWebhook"),
+	}
+	if c.tenant != "" {
+		attrs = append(attrs, attribute.String("kyc.tenant", c.tenant))
+	}
+	ctx, span := c.tracer.Start(ctx, "kyc.VerifyAndParseWebhook", trace.WithAttributes(attrs...))
+	defer func() { endSpan(span, err) }()
+	// 部分 Provider（例如 Onfido）验签后还要查询资源，HTTP 日志与指标记在 VerifyAndParseWebhook 名下。
+	ctx = httpclient.WithOperation(ctx, "VerifyAndParseWebhook")
+
+	payload, err := verifyWebhook(ctx, c.provider, headers, rawBody)
+	if err != nil {
+		c.metrics.ObserveWebhook(c.name, webhookOutcome(err))
+		c.logger.WarnContext(ctx, "kyc-sdk: webhook rejected", slog.String("provider", c.name), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if payload.Environment == "" {
+		payload.Environment = string(c.env)
+	}
+	c.observeWebhook(rawBody, payload)
+	span.SetAttributes(
+		attribute.String("kyc.webhook_type", payload.Type),
+		applicantAttr(payload.ApplicantID),
+		resultAttr(payload.ReviewResult),
+	)
+	return payload, nil
 }
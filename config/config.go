@@ -1,9 +1,88 @@
 package config
 
+import (
+	"net/http"
+
+	"github.com/dq/kyc-sdk/breaker"
+	"github.com/dq/kyc-sdk/ratelimit"
+)
+
 type Config struct {
-	BaseURL       string
-	AppToken      string
-	SecretKey     string
-	WebhookSecret string
-	TimeoutSec    int
+	// Provider 是 client.Register 注册的 Provider 名称（sumsub / onfido / veriff / jumio / persona），默认 sumsub。
+	Provider string `yaml:"provider" json:"provider" toml:"provider"`
+	// Environment 是 sandbox 或 production。设置后校验 Sumsub App Token 前缀（sbx: / prd:）是否一致，
+	// 为空时按 App Token 前缀推断（见 Env）。
+	Environment Environment `yaml:"environment" json:"environment" toml:"environment"`
+
+	// 以下为 Sumsub 配置。
+	BaseURL       string `yaml:"base_url" json:"base_url" toml:"base_url"`
+	AppToken      string `yaml:"app_token" json:"app_token" toml:"app_token"`
+	SecretKey     string `yaml:"secret_key" json:"secret_key" toml:"secret_key"`
+	WebhookSecret string `yaml:"webhook_secret" json:"webhook_secret" toml:"webhook_secret"`
+	TimeoutSec    int    `yaml:"timeout_sec" json:"timeout_sec" toml:"timeout_sec"`
+
+	// Secrets 在每次签名 / 验签时提供 AppToken、SecretKey、WebhookSecret（见 SecretSource），
+	// 优先于上面的同名字段；Provider 按 DefaultSecretTTL 缓存，传入 *SecretCache 时使用其自身的 TTL。
+	Secrets SecretSource `yaml:"-" json:"-" toml:"-"`
+
+	// Transport 是所有 Provider 发起 HTTP 请求使用的 RoundTripper，为空时使用 http.DefaultTransport。
+	// 可用于代理、录制 / 回放（见 kyctest/cassette）等场景。
+	Transport http.RoundTripper `yaml:"-" json:"-" toml:"-"`
+
+	// RateLimiter 是客户端限流器，为空时不限流。多个 Client 使用同一个 Limiter 时共享配额。
+	RateLimiter *ratelimit.Limiter `yaml:"-" json:"-" toml:"-"`
+	// CircuitBreaker 是熔断器，为空时不熔断。每个 Provider 应使用独立的 Breaker。
+	CircuitBreaker *breaker.Breaker `yaml:"-" json:"-" toml:"-"`
+
+	// Onfido 是 Onfido Provider 的配置，仅在使用 Onfido 时需要。
+	Onfido OnfidoConfig `yaml:"onfido" json:"onfido" toml:"onfido"`
+	// Veriff 是 Veriff Provider 的配置，仅在使用 Veriff 时需要。
+	Veriff VeriffConfig `yaml:"veriff" json:"veriff" toml:"veriff"`
+	// Jumio 是 Jumio Provider 的配置，仅在使用 Jumio 时需要。
+	Jumio JumioConfig `yaml:"jumio" json:"jumio" toml:"jumio"`
+	// Persona 是 Persona Provider 的配置，仅在使用 Persona 时需要。
+	Persona PersonaConfig `yaml:"persona" json:"persona" toml:"persona"`
+}
+
+// OnfidoConfig 是 Onfido Provider 的配置。
+type OnfidoConfig struct {
+	BaseURL      string `yaml:"base_url" json:"base_url" toml:"base_url"`                // 默认 https://api.eu.onfido.com
+	APIToken     string `yaml:"api_token" json:"api_token" toml:"api_token"`             // API Token
+	WebhookToken string `yaml:"webhook_token" json:"webhook_token" toml:"webhook_token"` // Webhook 验签 token
+	WorkflowID   string `yaml:"workflow_id" json:"workflow_id" toml:"workflow_id"`       // 默认 Studio workflow，GenerateLinkRequest.LevelName 非空时优先使用
+}
+
+// VeriffConfig 是 Veriff Provider 的配置。
+type VeriffConfig struct {
+	BaseURL      string `yaml:"base_url" json:"base_url" toml:"base_url"`                // 默认 https://stationapi.veriff.com
+	APIKey       string `yaml:"api_key" json:"api_key" toml:"api_key"`                   // API Key（X-AUTH-CLIENT）
+	SharedSecret string `yaml:"shared_secret" json:"shared_secret" toml:"shared_secret"` // 请求签名与 Webhook 验签共用的 shared secret
+	CallbackURL  string `yaml:"callback_url" json:"callback_url" toml:"callback_url"`    // 用户完成认证后的默认跳转地址，GenerateLinkRequest.SuccessURL 非空时优先使用
+}
+
+// JumioConfig 是 Jumio Provider 的配置。
+type JumioConfig struct {
+	Datacenter   string `yaml:"datacenter" json:"datacenter" toml:"datacenter"`          // 数据中心，例如 amer-1、eu-1、sg-1，默认 amer-1
+	BaseURL      string `yaml:"base_url" json:"base_url" toml:"base_url"`                // 账户 API 地址，默认 https://account.<Datacenter>.jumio.ai
+	AuthURL      string `yaml:"auth_url" json:"auth_url" toml:"auth_url"`                // OAuth2 地址，默认 https://auth.<Datacenter>.jumio.ai
+	RetrievalURL string `yaml:"retrieval_url" json:"retrieval_url" toml:"retrieval_url"` // 结果查询 API 地址，默认 https://retrieval.<Datacenter>.jumio.ai
+	ClientID     string `yaml:"client_id" json:"client_id" toml:"client_id"`             // OAuth2 client ID
+	ClientSecret string `yaml:"client_secret" json:"client_secret" toml:"client_secret"` // OAuth2 client secret
+	WorkflowKey  int    `yaml:"workflow_key" json:"workflow_key" toml:"workflow_key"`    // 默认 workflow definition key，GenerateLinkRequest.LevelName 非空时优先使用
+	CallbackURL  string `yaml:"callback_url" json:"callback_url" toml:"callback_url"`    // Jumio 回调地址
+	// WebhookUsername / WebhookPassword 是回调的 Basic Auth 凭据：创建 account 时写入回调地址，
+	// VerifyAndParseWebhook 校验回调的 Authorization header。未配置时 VerifyAndParseWebhook 拒绝所有回调。
+	WebhookUsername string `yaml:"webhook_username" json:"webhook_username" toml:"webhook_username"`
+	WebhookPassword string `yaml:"webhook_password" json:"webhook_password" toml:"webhook_password"`
+}
+
+// PersonaConfig 是 Persona Provider 的配置。
+type PersonaConfig struct {
+	BaseURL             string `yaml:"base_url" json:"base_url" toml:"base_url"`                                        // 默认 https://withpersona.com
+	HostedFlowURL       string `yaml:"hosted_flow_url" json:"hosted_flow_url" toml:"hosted_flow_url"`                   // 托管页面地址，默认 https://withpersona.com/verify
+	APIKey              string `yaml:"api_key" json:"api_key" toml:"api_key"`                                           // API Key
+	WebhookSecret       string `yaml:"webhook_secret" json:"webhook_secret" toml:"webhook_secret"`                      // Webhook 验签 secret
+	TemplateID          string `yaml:"template_id" json:"template_id" toml:"template_id"`                               // 默认 inquiry template，GenerateLinkRequest.LevelName 非空时优先使用
+	EnvironmentID       string `yaml:"environment_id" json:"environment_id" toml:"environment_id"`                      // 托管页面使用的 environment（可选）
+	WebhookToleranceSec int    `yaml:"webhook_tolerance_sec" json:"webhook_tolerance_sec" toml:"webhook_tolerance_sec"` // Webhook 时间戳允许的偏差（秒），默认 300
 }
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("KYC_APP_TOKEN", "sbx:token")
+	t.Setenv("KYC_SECRET_KEY", "secret")
+	t.Setenv("KYC_TIMEOUT_SEC", "5")
+	t.Setenv("KYC_ONFIDO_API_TOKEN", "onfido-token")
+
+	cfg, err := FromEnv("KYC")
+	if err != nil {
+		t.Fatalf("FromEnv: %v", err)
+	}
+	if cfg.BaseURL != DefaultSumsubBaseURL || cfg.Provider != DefaultProvider {
+		t.Fatalf("expected defaults, got %+v", cfg)
+	}
+	if cfg.AppToken != "sbx:token" || cfg.TimeoutSec != 5 || cfg.Onfido.APIToken != "onfido-token" {
+		t.Fatalf("env not applied: %s", cfg)
+	}
+	if !cfg.IsSandbox() {
+		t.Fatalf("expected sandbox from sbx: prefix")
+	}
+
+	t.Setenv("KYC_TIMEOUT_SEC", "ten")
+	if _, err := FromEnv("KYC_"); !errors.Is(err, kycerrors.ErrInvalidConfig) || !strings.Contains(err.Error(), "KYC_TIMEOUT_SEC") {
+		t.Fatalf("expected invalid integer error, got: %v", err)
+	}
+}
+
+func TestLoad_Formats(t *testing.T) {
+	files := map[string]string{
+		"kyc.yaml": "app_token: prd:token\nsecret_key: secret\nonfido:\n  workflow_id: wf-1\n",
+		"kyc.json": `{"app_token":"prd:token","secret_key":"secret","onfido":{"workflow_id":"wf-1"}}`,
+		"kyc.toml": "app_token = \"prd:token\"\nsecret_key = \"secret\"\n[onfido]\nworkflow_id = \"wf-1\"\n",
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.AppToken != "prd:token" || cfg.Onfido.WorkflowID != "wf-1" || cfg.TimeoutSec != DefaultTimeoutSec {
+				t.Fatalf("unexpected config: %s", cfg)
+			}
+			if cfg.IsSandbox() {
+				t.Fatalf("prd token must not be sandbox")
+			}
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "typo.yaml")
+	if err := os.WriteFile(path, []byte("app_tokn: x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); !errors.Is(err, kycerrors.ErrInvalidConfig) {
+		t.Fatalf("expected unknown field to be rejected, got: %v", err)
+	}
+}
+
+func TestValidate_ReportsAllProblems(t *testing.T) {
+	cfg := &Config{BaseURL: "api.sumsub.com", TimeoutSec: -1}
+	err := cfg.Validate()
+	if !errors.Is(err, kycerrors.ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got: %v", err)
+	}
+	for _, want := range []string{"TimeoutSec", "BaseURL must be an absolute", "AppToken required", "SecretKey required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("missing %q in:\n%v", want, err)
+		}
+	}
+
+	veriff := &Config{Provider: "veriff", Veriff: VeriffConfig{APIKey: "k", SharedSecret: "s"}}
+	if err := veriff.Validate(); err != nil {
+		t.Fatalf("expected valid veriff config, got: %v", err)
+	}
+	if err := (&Config{Provider: "custom"}).Validate(); err != nil {
+		t.Fatalf("expected custom provider to skip provider checks, got: %v", err)
+	}
+}
+
+func TestString_RedactsSecrets(t *testing.T) {
+	cfg := Config{
+		BaseURL:  DefaultSumsubBaseURL,
+		AppToken: "sbx:app-token",
+		Jumio:    JumioConfig{ClientID: "client", ClientSecret: "jumio-secret"},
+	}
+	for _, out := range []string{cfg.String(), fmt.Sprintf("%v", &cfg), fmt.Sprintf("%#v", cfg)} {
+		for _, leak := range []string{"app-token", "jumio-secret"} {
+			if strings.Contains(out, leak) {
+				t.Fatalf("String leaks %q: %s", leak, out)
+			}
+		}
+		if !strings.Contains(out, `jumio.client_id="client"`) || !strings.Contains(out, `environment="sandbox"`) {
+			t.Fatalf("unexpected String: %s", out)
+		}
+	}
+}
+
+func TestValidate_Environment(t *testing.T) {
+	cfg := &Config{BaseURL: DefaultSumsubBaseURL, AppToken: "sbx:token", SecretKey: "secret", Environment: EnvProduction}
+	err := cfg.Validate()
+	if !errors.Is(err, kycerrors.ErrEnvironmentMismatch) || !errors.Is(err, kycerrors.ErrInvalidConfig) {
+		t.Fatalf("expected environment mismatch, got: %v", err)
+	}
+
+	cfg.AppToken = "prd:token"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if cfg.IsSandbox() {
+		t.Fatalf("expected production")
+	}
+
+	cfg.Environment = "staging"
+	if err := cfg.Validate(); !errors.Is(err, kycerrors.ErrInvalidConfig) {
+		t.Fatalf("expected invalid Environment, got: %v", err)
+	}
+
+	cfg.Environment = ""
+	if got := cfg.Env(); got != EnvProduction {
+		t.Fatalf("expected environment inferred from token, got %q", got)
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+)
+
+// Environment 区分 Provider 的 sandbox 与 production 环境。
+type Environment string
+
+const (
+	EnvSandbox    Environment = "sandbox"
+	EnvProduction Environment = "production"
+)
+
+// Sumsub App Token 的环境前缀。
+const (
+	SandboxTokenPrefix    = "sbx:"
+	ProductionTokenPrefix = "prd:"
+)
+
+func (e Environment) valid() bool {
+	return e == EnvSandbox || e == EnvProduction
+}
+
+// EnvironmentFromToken 按 Sumsub App Token 前缀判断环境，无法判断时返回空。
+func EnvironmentFromToken(appToken string) Environment {
+	switch {
+	case strings.HasPrefix(appToken, SandboxTokenPrefix):
+		return EnvSandbox
+	case strings.HasPrefix(appToken, ProductionTokenPrefix):
+		return EnvProduction
+	default:
+		return ""
+	}
+}
+
+// CheckToken 校验 Sumsub App Token 的前缀与环境一致，不一致时返回包装了 kycerrors.ErrEnvironmentMismatch 的错误。
+func (e Environment) CheckToken(appToken string) error {
+	if !e.valid() {
+		return nil
+	}
+	if got := EnvironmentFromToken(appToken); got != e {
+		want := SandboxTokenPrefix
+		if e == EnvProduction {
+			want = ProductionTokenPrefix
+		}
+		return fmt.Errorf("%w: Environment is %s but AppToken does not start with %q", kycerrors.ErrEnvironmentMismatch, e, want)
+	}
+	return nil
+}
+
+// Env 返回配置的环境：优先使用 Environment，否则按 App Token 前缀推断，都无法确定时返回空。
+func (c *Config) Env() Environment {
+	if c == nil {
+		return ""
+	}
+	if c.Environment != "" {
+		return c.Environment
+	}
+	return EnvironmentFromToken(c.AppToken)
+}
+
+// IsSandbox 判断是否为 sandbox 环境（见 Env）。
+func (c *Config) IsSandbox() bool {
+	return c.Env() == EnvSandbox
+}
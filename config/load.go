@@ -0,0 +1,132 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+)
+
+const (
+	DefaultProvider      = "sumsub"
+	DefaultSumsubBaseURL = "https://api.sumsub.com"
+	DefaultTimeoutSec    = 10
+)
+
+// Default 返回带默认值的配置：Provider 为 sumsub，Sumsub BaseURL 为正式地址，超时 10 秒。
+func Default() *Config {
+	return &Config{
+		Provider:   DefaultProvider,
+		BaseURL:    DefaultSumsubBaseURL,
+		TimeoutSec: DefaultTimeoutSec,
+	}
+}
+
+// FromEnv 在默认配置上读取环境变量并校验。变量名为 prefix 加上字段的 snake_case 名称（大写），
+// 子配置再加一级，例如 prefix 为 KYC 时：KYC_APP_TOKEN、KYC_TIMEOUT_SEC、KYC_ONFIDO_API_TOKEN。
+// 未设置的变量保持默认值。
+func FromEnv(prefix string) (*Config, error) {
+	cfg := Default()
+	prefix = strings.TrimSuffix(prefix, "_")
+
+	var errs []error
+	walk(reflect.ValueOf(cfg).Elem(), nil, func(path []string, f reflect.Value) {
+		name := strings.ToUpper(strings.Join(path, "_"))
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return
+		}
+
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString(strings.TrimSpace(v))
+		case reflect.Int:
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%w: %s: invalid integer %q", kycerrors.ErrInvalidConfig, name, v))
+				return
+			}
+			f.SetInt(int64(n))
+		}
+	})
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Load 在默认配置上读取配置文件并校验，按扩展名识别格式（.yaml / .yml / .json / .toml）。
+// 文件中的未知字段视为错误，避免拼写错误被静默忽略。
+func Load(path string) (*Config, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(bs))
+		dec.KnownFields(true)
+		err = dec.Decode(cfg)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(bs))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(cfg)
+	case ".toml":
+		var md toml.MetaData
+		md, err = toml.Decode(string(bs), cfg)
+		if err == nil {
+			if undecoded := md.Undecoded(); len(undecoded) > 0 {
+				err = fmt.Errorf("unknown fields %v", undecoded)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported config format %q", kycerrors.ErrInvalidConfig, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", kycerrors.ErrInvalidConfig, path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// walk 遍历带 json tag 的字符串 / 整数字段，path 为各级 tag 名称。
+func walk(v reflect.Value, path []string, fn func(path []string, f reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "" || name == "-" || !sf.IsExported() {
+			continue
+		}
+
+		p := append(append([]string(nil), path...), name)
+		switch f := v.Field(i); f.Kind() {
+		case reflect.Struct:
+			walk(f, p, fn)
+		case reflect.String, reflect.Int:
+			fn(p, f)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config.Secrets 查询的凭据名称。
+const (
+	SecretAppToken      = "app_token"
+	SecretSecretKey     = "secret_key"
+	SecretWebhookSecret = "webhook_secret"
+)
+
+// DefaultSecretTTL 是 Provider 缓存凭据的默认时长，过期后重新读取，使轮换后的凭据生效。
+const DefaultSecretTTL = time.Minute
+
+// ErrSecretNotFound 表示 SecretSource 中没有该凭据，此时使用 Config 中的同名字段。
+var ErrSecretNotFound = errors.New("kyc-sdk: secret not found")
+
+// SecretSource 按名称提供凭据（SecretAppToken 等）。Vault、KMS 等外部系统实现该接口即可接入，
+// 实现需要并发安全。
+type SecretSource interface {
+	Secret(ctx context.Context, name string) (string, error)
+}
+
+// SecretFunc 把函数适配为 SecretSource。
+type SecretFunc func(ctx context.Context, name string) (string, error)
+
+func (f SecretFunc) Secret(ctx context.Context, name string) (string, error) {
+	return f(ctx, name)
+}
+
+// EnvSecrets 从环境变量读取凭据，变量名为 prefix 加上大写的凭据名，例如 KYC_SECRET_KEY。
+// 每次调用都重新读取。
+func EnvSecrets(prefix string) SecretSource {
+	prefix = strings.TrimSuffix(prefix, "_")
+	return SecretFunc(func(_ context.Context, name string) (string, error) {
+		key := strings.ToUpper(name)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		v, ok := os.LookupEnv(key)
+		if !ok || strings.TrimSpace(v) == "" {
+			return "", fmt.Errorf("%w: env %s", ErrSecretNotFound, key)
+		}
+		return strings.TrimSpace(v), nil
+	})
+}
+
+// FileSecrets 从 dir 下与凭据同名的文件读取（例如 Kubernetes Secret 挂载的 dir/secret_key），
+// 去掉首尾空白，文件为空或只有空白时返回 ErrSecretNotFound。每次调用都重新读取，文件更新后立即生效。
+func FileSecrets(dir string) SecretSource {
+	return SecretFunc(func(_ context.Context, name string) (string, error) {
+		bs, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w: file %s", ErrSecretNotFound, name)
+		}
+		if err != nil {
+			return "", err
+		}
+		v := strings.TrimSpace(string(bs))
+		if v == "" {
+			// 挂载中途或写坏的空文件不能当作空密钥使用。
+			return "", fmt.Errorf("%w: file %s is empty", ErrSecretNotFound, name)
+		}
+		return v, nil
+	})
+}
+
+// SecretCache 缓存 src 返回的凭据 ttl 时长。过期后重新读取；读取失败时继续使用上一次的值，
+// 避免密钥系统短暂不可用影响请求。
+type SecretCache struct {
+	src SecretSource
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]secretEntry
+}
+
+type secretEntry struct {
+	value   string
+	expires time.Time
+}
+
+// CacheSecrets 包装 src，ttl <= 0 时使用 DefaultSecretTTL。
+func CacheSecrets(src SecretSource, ttl time.Duration) *SecretCache {
+	if ttl <= 0 {
+		ttl = DefaultSecretTTL
+	}
+	return &SecretCache{src: src, ttl: ttl, now: time.Now, entries: make(map[string]secretEntry)}
+}
+
+func (c *SecretCache) Secret(ctx context.Context, name string) (string, error) {
+	c.mu.Lock()
+	e, ok := c.entries[name]
+	c.mu.Unlock()
+	if ok && c.now().Before(e.expires) {
+		return e.value, nil
+	}
+
+	v, err := c.src.Secret(ctx, name)
+	if err != nil {
+		if ok && !errors.Is(err, ErrSecretNotFound) {
+			return e.value, nil
+		}
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.entries[name] = secretEntry{value: v, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return v, nil
+}
+
+// Invalidate 清空缓存，下次使用时重新读取（例如收到轮换通知时）。
+func (c *SecretCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// ResolveSecret 从 c.Secrets 读取 name 对应的凭据；未配置 Secrets 或返回 ErrSecretNotFound 时使用 fallback。
+func (c *Config) ResolveSecret(ctx context.Context, name, fallback string) (string, error) {
+	if c == nil || c.Secrets == nil {
+		return fallback, nil
+	}
+	v, err := c.Secrets.Secret(ctx, name)
+	if errors.Is(err, ErrSecretNotFound) {
+		return fallback, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("load secret %s: %w", name, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEnvAndFileSecrets(t *testing.T) {
+	t.Setenv("KYC_SECRET_KEY", " from-env \n")
+	if v, err := EnvSecrets("KYC").Secret(context.Background(), SecretSecretKey); err != nil || v != "from-env" {
+		t.Fatalf("EnvSecrets = %q, %v", v, err)
+	}
+	if _, err := EnvSecrets("KYC").Secret(context.Background(), SecretAppToken); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound, got: %v", err)
+	}
+
+	dir := t.TempDir()
+	src := FileSecrets(dir)
+	if _, err := src.Secret(context.Background(), SecretWebhookSecret); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound, got: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, SecretWebhookSecret), []byte("v1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := src.Secret(context.Background(), SecretWebhookSecret); v != "v1" {
+		t.Fatalf("FileSecrets = %q", v)
+	}
+	if err := os.WriteFile(filepath.Join(dir, SecretWebhookSecret), []byte(" \n\t"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.Secret(context.Background(), SecretWebhookSecret); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound for whitespace-only file, got: %v", err)
+	}
+}
+
+func TestSecretCache(t *testing.T) {
+	value, fail := "v1", error(nil)
+	calls := 0
+	src := SecretFunc(func(context.Context, string) (string, error) {
+		calls++
+		return value, fail
+	})
+
+	now := time.Unix(1_700_000_000, 0)
+	cache := CacheSecrets(src, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	get := func() string {
+		t.Helper()
+		v, err := cache.Secret(context.Background(), SecretSecretKey)
+		if err != nil {
+			t.Fatalf("Secret: %v", err)
+		}
+		return v
+	}
+
+	get()
+	value = "v2"
+	if v := get(); v != "v1" || calls != 1 {
+		t.Fatalf("expected cached v1 after 1 call, got %s after %d", v, calls)
+	}
+
+	now = now.Add(time.Minute)
+	if v := get(); v != "v2" {
+		t.Fatalf("expected reload after ttl, got %s", v)
+	}
+
+	// 密钥系统暂时不可用时继续使用旧值。
+	now = now.Add(time.Minute)
+	fail = errors.New("vault unavailable")
+	if v := get(); v != "v2" {
+		t.Fatalf("expected stale value on error, got %s", v)
+	}
+
+	fail, value = nil, "v3"
+	cache.Invalidate()
+	if v := get(); v != "v3" {
+		t.Fatalf("expected reload after Invalidate, got %s", v)
+	}
+}
+
+func TestResolveSecret_FallsBackToField(t *testing.T) {
+	cfg := &Config{SecretKey: "static", Secrets: SecretFunc(func(_ context.Context, name string) (string, error) {
+		if name == SecretAppToken {
+			return "dynamic", nil
+		}
+		return "", ErrSecretNotFound
+	})}
+
+	if v, _ := cfg.ResolveSecret(context.Background(), SecretAppToken, cfg.AppToken); v != "dynamic" {
+		t.Fatalf("expected dynamic, got %s", v)
+	}
+	if v, _ := cfg.ResolveSecret(context.Background(), SecretSecretKey, cfg.SecretKey); v != "static" {
+		t.Fatalf("expected fallback to static, got %s", v)
+	}
+}
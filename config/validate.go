@@ -0,0 +1,143 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+)
+
+// secretFields 是 String 中不输出原值的字段（json tag 名称）。
+var secretFields = map[string]bool{
+	"app_token":        true,
+	"secret_key":       true,
+	"webhook_secret":   true,
+	"api_token":        true,
+	"webhook_token":    true,
+	"api_key":          true,
+	"shared_secret":    true,
+	"client_secret":    true,
+	"webhook_password": true,
+}
+
+// Validate 按 c.Provider（为空时为 sumsub）校验配置，一次返回所有问题（errors.Join），
+// 每个问题都包装了 kycerrors.ErrInvalidConfig。未知的 Provider（例如自行注册的）只做通用校验。
+func (c *Config) Validate() error {
+	if c == nil {
+		return fmt.Errorf("%w: nil", kycerrors.ErrInvalidConfig)
+	}
+	return c.ValidateFor(c.Provider)
+}
+
+// ValidateFor 与 Validate 相同，但按指定的 Provider 校验。
+func (c *Config) ValidateFor(provider string) error {
+	if c == nil {
+		return fmt.Errorf("%w: nil", kycerrors.ErrInvalidConfig)
+	}
+
+	var v validator
+	if c.TimeoutSec < 0 {
+		v.add("TimeoutSec must not be negative")
+	}
+	if c.Environment != "" && !c.Environment.valid() {
+		v.add("Environment must be %q or %q, got %q", EnvSandbox, EnvProduction, c.Environment)
+	}
+
+	switch provider {
+	case "", "sumsub":
+		v.url("BaseURL", c.BaseURL, true)
+		// 使用 Secrets 时凭据在请求时读取，无法提前校验。
+		if c.Secrets == nil {
+			v.required("AppToken", c.AppToken)
+			v.required("SecretKey", c.SecretKey)
+		}
+		if c.Environment.valid() && c.AppToken != "" {
+			if err := c.Environment.CheckToken(c.AppToken); err != nil {
+				v.add("%w", err)
+			}
+		}
+	case "onfido":
+		v.url("Onfido.BaseURL", c.Onfido.BaseURL, false)
+		v.required("Onfido.APIToken", c.Onfido.APIToken)
+	case "veriff":
+		v.url("Veriff.BaseURL", c.Veriff.BaseURL, false)
+		v.required("Veriff.APIKey", c.Veriff.APIKey)
+		v.required("Veriff.SharedSecret", c.Veriff.SharedSecret)
+	case "jumio":
+		v.url("Jumio.BaseURL", c.Jumio.BaseURL, false)
+		v.url("Jumio.AuthURL", c.Jumio.AuthURL, false)
+		v.url("Jumio.RetrievalURL", c.Jumio.RetrievalURL, false)
+		v.required("Jumio.ClientID", c.Jumio.ClientID)
+		v.required("Jumio.ClientSecret", c.Jumio.ClientSecret)
+		if (c.Jumio.WebhookUsername == "") != (c.Jumio.WebhookPassword == "") {
+			v.add("Jumio.WebhookUsername and Jumio.WebhookPassword must be set together")
+		}
+	case "persona":
+		v.url("Persona.BaseURL", c.Persona.BaseURL, false)
+		v.url("Persona.HostedFlowURL", c.Persona.HostedFlowURL, false)
+		v.required("Persona.APIKey", c.Persona.APIKey)
+	}
+	return errors.Join(v.errs...)
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) add(format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf("%w: "+format, append([]any{kycerrors.ErrInvalidConfig}, args...)...))
+}
+
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add("%s required", field)
+	}
+}
+
+// url 校验绝对的 http(s) 地址；required 为 false 时允许为空（使用 Provider 默认值）。
+func (v *validator) url(field, value string, required bool) {
+	if strings.TrimSpace(value) == "" {
+		if required {
+			v.add("%s required", field)
+		}
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		v.add("%s must be an absolute http(s) URL, got %q", field, value)
+	}
+}
+
+// String 输出非空字段，secret 一律替换为 REDACTED，可以安全地写入日志。
+func (c Config) String() string {
+	parts := make([]string, 0, 8)
+	walk(reflect.ValueOf(&c).Elem(), nil, func(path []string, f reflect.Value) {
+		if f.IsZero() {
+			return
+		}
+		name := strings.Join(path, ".")
+		switch {
+		case secretFields[path[len(path)-1]]:
+			parts = append(parts, name+"=REDACTED")
+		case f.Kind() == reflect.Int:
+			parts = append(parts, name+"="+strconv.FormatInt(f.Int(), 10))
+		default:
+			parts = append(parts, name+"="+strconv.Quote(f.String()))
+		}
+	})
+	if c.Environment == "" {
+		if env := c.Env(); env != "" {
+			parts = append(parts, "environment="+strconv.Quote(string(env)))
+		}
+	}
+	return "config.Config{" + strings.Join(parts, " ") + "}"
+}
+
+// GoString 使 %#v 同样不输出 secret。
+func (c Config) GoString() string {
+	return c.String()
+}
@@ -9,11 +9,10 @@ import (
 )
 
 func main() {
+	// 凭据从环境变量 KYC_APP_TOKEN / KYC_SECRET_KEY / KYC_WEBHOOK_SECRET 读取，不要写在代码里。
 	cfg := &config.Config{
-		BaseURL:       "https://api.sumsub.com",
-		AppToken:      "sbx:9MjMj2UIBmYA7AZzgn70f4VB.RzzRbgAW8Ckex24KUuCduSmJrlhxmDIw",
-		SecretKey:     "j7ztQmVlNJRzN0vVomTeKAwDbznk9fAE",
-		WebhookSecret: "",
+		BaseURL: "https://api.sumsub.com",
+		Secrets: config.EnvSecrets("KYC"),
 	}
 
 	cli, err := client.NewClient(cfg)
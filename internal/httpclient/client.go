@@ -5,30 +5,68 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dq/kyc-sdk/breaker"
 	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/ratelimit"
 )
 
 type Client struct {
 	baseURL string
 	http    *http.Client
+	timeout time.Duration
+	limiter *ratelimit.Limiter
+	breaker *breaker.Breaker
 }
 
-func New(baseURL string, timeoutSec int) *Client {
+// Option 定制 Client。
+type Option func(*Client)
+
+// WithTransport 替换底层的 http.RoundTripper（例如录制 / 回放），rt 为 nil 时不生效。
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		if rt != nil {
+			c.http.Transport = rt
+		}
+	}
+}
+
+// WithRateLimiter 在发出请求前按接口分组等待配额，l 为 nil 时不限流。
+func WithRateLimiter(l *ratelimit.Limiter) Option {
+	return func(c *Client) {
+		c.limiter = l
+	}
+}
+
+// WithCircuitBreaker 在 Provider 持续失败时快速失败，b 为 nil 时不熔断。
+func WithCircuitBreaker(b *breaker.Breaker) Option {
+	return func(c *Client) {
+		c.breaker = b
+	}
+}
+
+func New(baseURL string, timeoutSec int, opts ...Option) *Client {
 	if timeoutSec == 0 {
 		timeoutSec = 10
 	}
 
-	return &Client{
+	// 超时由 do 通过 ctx 控制，以便单次调用用 WithTimeout 覆盖。
+	c := &Client{
 		baseURL: strings.TrimRight(baseURL, "/"),
-		http: &http.Client{
-			Timeout: time.Duration(timeoutSec) * time.Second,
-		},
+		http:    &http.Client{},
+		timeout: time.Duration(timeoutSec) * time.Second,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *Client) GetJSON(ctx context.Context, path string, headers map[string]string, out any) error {
@@ -41,39 +79,150 @@ func (c *Client) GetJSON(ctx context.Context, path string, headers map[string]st
 		req.Header.Set(k, v)
 	}
 
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	return decode(resp, out)
+	return c.do(req, out)
 }
 
 func (c *Client) PostJSON(ctx context.Context, path string, body any, headers map[string]string, out any) error {
-	bs, err := json.Marshal(body)
+	var r io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, r)
 	if err != nil {
 		return err
 	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	return c.do(req, out)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(bs))
+func (c *Client) PostForm(ctx context.Context, path string, form url.Values, headers map[string]string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, strings.NewReader(form.Encode()))
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/json")
 
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
 
-	resp, err := c.http.Do(req)
+	return c.do(req, out)
+}
+
+func (c *Client) do(req *http.Request, out any) error {
+	callerCtx := req.Context()
+	requestID := RequestID(callerCtx)
+	if requestID != "" && req.Header.Get("X-Request-Id") == "" {
+		req.Header.Set("X-Request-Id", requestID)
+	}
+	for k, vs := range RequestHeaders(callerCtx) {
+		if _, exists := req.Header[http.CanonicalHeaderKey(k)]; exists {
+			continue
+		}
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+
+	done, err := c.breaker.Allow()
 	if err != nil {
-		return err
+		return withRequestID(err, requestID)
+	}
+
+	group := rateLimitGroup(req)
+	if err := c.limiter.Wait(callerCtx, group); err != nil {
+		done(breaker.Ignore)
+		return withRequestID(err, requestID)
+	}
+
+	timeout := c.timeout
+	if d := Timeout(callerCtx); d > 0 {
+		timeout = d
+	}
+	ctx, cancel := context.WithTimeout(callerCtx, timeout)
+	defer cancel()
+
+	resp, err := c.http.Do(req.WithContext(ctx))
+	if err != nil {
+		// 调用方取消或超时与 Provider 是否可用无关；请求自身的超时计为失败。
+		if callerCtx.Err() != nil {
+			done(breaker.Ignore)
+		} else {
+			done(breaker.Failure)
+		}
+		return withRequestID(err, requestID)
 	}
 	defer resp.Body.Close()
 
-	return decode(resp, out)
+	if resp.StatusCode >= 500 {
+		done(breaker.Failure)
+	} else {
+		done(breaker.Success)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		c.limiter.Throttled(group, retryAfter(resp.Header))
+	case resp.StatusCode < 400:
+		c.limiter.Succeeded(group)
+	}
+
+	err = decode(resp, out)
+	var httpErr *kycerrors.HTTPError
+	if errors.As(err, &httpErr) {
+		httpErr.RequestID = requestID
+		return err
+	}
+	return withRequestID(err, requestID)
+}
+
+// withRequestID 在非 HTTP 错误（网络错误、超时、熔断等）上附带请求 ID。
+func withRequestID(err error, requestID string) error {
+	if err == nil || requestID == "" {
+		return err
+	}
+	return fmt.Errorf("%w (request_id=%s)", err, requestID)
+}
+
+// rateLimitGroup 按 Client 操作与请求方法选择限流分组。
+func rateLimitGroup(req *http.Request) ratelimit.Group {
+	switch {
+	case Operation(req.Context()) == "GenerateLink":
+		return ratelimit.GroupLink
+	case req.Method == http.MethodGet:
+		return ratelimit.GroupRead
+	default:
+		return ratelimit.GroupWrite
+	}
+}
+
+// retryAfter 解析 Retry-After（秒数或 HTTP 日期），缺失或无法解析时返回 0。
+func retryAfter(h http.Header) time.Duration {
+	v := strings.TrimSpace(h.Get("Retry-After"))
+	if v == "" {
+		return 0
+	}
+	if sec, err := strconv.Atoi(v); err == nil {
+		return time.Duration(sec) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
 }
 
 func decode(resp *http.Response, out any) error {
@@ -82,6 +231,7 @@ func decode(resp *http.Response, out any) error {
 		return &kycerrors.HTTPError{
 			StatusCode: resp.StatusCode,
 			Body:       body,
+			RetryAfter: retryAfter(resp.Header),
 		}
 	}
 
@@ -107,3 +257,71 @@ func readBody(r io.Reader, limit int64) string {
 	}
 	return strings.TrimSpace(string(bs))
 }
+
+type attemptKey struct{}
+
+// WithAttempt 标记本次请求是第几次尝试（从 1 开始），供日志、追踪与重试指标使用。
+// Client 本身不自动重试，只有上层重发请求（例如 Jumio 刷新 token 后）时才会大于 1。
+func WithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// Attempt 返回 WithAttempt 设置的尝试次数，未设置时为 1。
+func Attempt(ctx context.Context) int {
+	if n, ok := ctx.Value(attemptKey{}).(int); ok && n > 0 {
+		return n
+	}
+	return 1
+}
+
+type operationKey struct{}
+
+// WithOperation 标记请求所属的 Client 操作（例如 CreateApplicant），供指标等使用。
+func WithOperation(ctx context.Context, op string) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// Operation 返回 WithOperation 设置的操作名，未设置时为空。
+func Operation(ctx context.Context) string {
+	op, _ := ctx.Value(operationKey{}).(string)
+	return op
+}
+
+type timeoutKey struct{}
+
+// WithTimeout 覆盖本次请求的超时（替代 New 的 timeoutSec）。
+func WithTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// Timeout 返回 WithTimeout 设置的超时，未设置时为 0。
+func Timeout(ctx context.Context) time.Duration {
+	d, _ := ctx.Value(timeoutKey{}).(time.Duration)
+	return d
+}
+
+type requestIDKey struct{}
+
+// WithRequestID 设置请求 ID：以 X-Request-Id 发送，并附带在返回的错误中。
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID 返回 WithRequestID 设置的请求 ID，未设置时为空。
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type requestHeadersKey struct{}
+
+// WithRequestHeaders 为请求追加 header，请求上已有的 header 不会被覆盖。
+func WithRequestHeaders(ctx context.Context, h http.Header) context.Context {
+	return context.WithValue(ctx, requestHeadersKey{}, h)
+}
+
+// RequestHeaders 返回 WithRequestHeaders 设置的 header，未设置时为 nil。
+func RequestHeaders(ctx context.Context) http.Header {
+	h, _ := ctx.Value(requestHeadersKey{}).(http.Header)
+	return h
+}
@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/dq/kyc-sdk/breaker"
 	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/ratelimit"
 )
 
 func TestGetJSON_UnauthorizedMapped(t *testing.T) {
@@ -54,3 +57,77 @@ func TestGetJSON_InvalidJSON(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestRateLimiter_ThrottledOn429(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	cli := New(srv.URL, 1, WithRateLimiter(ratelimit.New(ratelimit.DefaultLimits())))
+	err := cli.PostJSON(context.Background(), "/x", nil, nil, nil)
+	var httpErr *kycerrors.HTTPError
+	if !errors.As(err, &httpErr) || !errors.Is(err, kycerrors.ErrRateLimited) {
+		t.Fatalf("expected rate limited HTTPError, got: %v", err)
+	}
+	if httpErr.RetryAfter != 30*time.Second {
+		t.Fatalf("expected RetryAfter 30s, got %v", httpErr.RetryAfter)
+	}
+
+	// 写分组暂停到 Retry-After 之后，请求在发出前就因 ctx 超时而放弃。
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := cli.PostJSON(ctx, "/x", nil, nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got: %v", err)
+	}
+	// 读分组不受影响。
+	if err := cli.GetJSON(context.Background(), "/x", nil, nil); !errors.Is(err, kycerrors.ErrRateLimited) {
+		t.Fatalf("expected read request to reach server, got: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 server calls, got %d", calls)
+	}
+}
+
+func TestCircuitBreaker_FailsFast(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cli := New(srv.URL, 1, WithCircuitBreaker(breaker.New(breaker.Settings{Name: "test", MinRequests: 2})))
+	for i := 0; i < 2; i++ {
+		if err := cli.GetJSON(context.Background(), "/x", nil, nil); !errors.Is(err, kycerrors.ErrServerInternal) {
+			t.Fatalf("expected ErrServerInternal, got: %v", err)
+		}
+	}
+	if err := cli.GetJSON(context.Background(), "/x", nil, nil); !errors.Is(err, kycerrors.ErrProviderUnavailable) {
+		t.Fatalf("expected ErrProviderUnavailable, got: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected open circuit to skip the server, got %d calls", calls)
+	}
+}
+
+func TestRouteTemplate(t *testing.T) {
+	cases := map[string]string{
+		"/resources/applicants":                                                           "/resources/applicants",
+		"/resources/applicants/5cb56e8e0a975a35f333cb83/one":                              "/resources/applicants/{id}/one",
+		"/resources/applicants/-;externalUserId=user%40example.com/one":                   "/resources/applicants/{id}/one",
+		"/resources/sdkIntegrations/levels/-/websdkLink":                                  "/resources/sdkIntegrations/levels/-/websdkLink",
+		"/v3.6/workflow_runs/0f8a6c1e-3b2d-4c55-9e1a-7d2b9c4e5f60":                        "/v3.6/workflow_runs/{id}",
+		"/api/v1/accounts/acc-1/workflow-executions/6a1f2c3d-0000-4000-8000-000000000001": "/api/v1/accounts/{id}/workflow-executions/{id}",
+		"/api/v1/inquiries/inq_9Xk2mPq7":                                                  "/api/v1/inquiries/{id}",
+		"/oauth2/token":                                                                   "/oauth2/token",
+	}
+	for path, want := range cases {
+		if got := routeTemplate(path); got != want {
+			t.Fatalf("routeTemplate(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
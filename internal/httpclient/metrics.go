@@ -0,0 +1,36 @@
+package httpclient
+
+import (
+	"net/http"
+
+	"github.com/dq/kyc-sdk/metrics"
+)
+
+// MetricsTransport 按 HTTP 尝试记录限流（429）与重试次数。
+type MetricsTransport struct {
+	next     http.RoundTripper
+	recorder metrics.Recorder
+	provider string
+}
+
+// NewMetricsTransport 包装 next（为 nil 时使用 http.DefaultTransport）。
+func NewMetricsTransport(next http.RoundTripper, recorder metrics.Recorder, provider string) *MetricsTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &MetricsTransport{next: next, recorder: recorder, provider: provider}
+}
+
+func (t *MetricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	op := Operation(ctx)
+	if Attempt(ctx) > 1 {
+		t.recorder.IncRetry(t.provider, op)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		t.recorder.IncRateLimited(t.provider, op)
+	}
+	return resp, err
+}
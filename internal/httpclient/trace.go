@@ -0,0 +1,81 @@
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingTransport 为每次 HTTP 尝试创建一个 client span，并以 W3C trace-context 向下游传播。
+type TracingTransport struct {
+	next       http.RoundTripper
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	provider   string
+}
+
+// NewTracingTransport 包装 next（为 nil 时使用 http.DefaultTransport）。
+func NewTracingTransport(next http.RoundTripper, tracer trace.Tracer, provider string) *TracingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &TracingTransport{
+		next:       next,
+		tracer:     tracer,
+		propagator: propagation.TraceContext{},
+		provider:   provider,
+	}
+}
+
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("kyc.provider", t.provider),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.String("url.path", routeTemplate(req.URL.Path)),
+			attribute.Int("kyc.attempt", Attempt(req.Context())),
+		),
+	)
+	defer span.End()
+
+	// RoundTripper 不应修改调用方的请求，注入 header 前先复制。
+	req = req.Clone(ctx)
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, fmt.Sprintf("http %d", resp.StatusCode))
+	}
+	return resp, nil
+}
+
+// staticSegment 匹配路径中的固定部分：由 - / _ 连接的纯字母单词（可带 oauth2 这样的一位数字后缀）、
+// 版本号（v1、v3.6）与 Sumsub 的占位段 "-"。
+var staticSegment = regexp.MustCompile(`^(-|v\d+(\.\d+)*|[A-Za-z]+([_-][A-Za-z]+)*\d?)$`)
+
+// routeTemplate 把路径中的 ID 段（applicant ID、UUID、-;externalUserId=<id> 等）替换为 {id}，
+// 避免在 trace 中暴露原始 ID，同时让同一路由的 span 可以聚合。
+func routeTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if seg != "" && !staticSegment.MatchString(seg) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
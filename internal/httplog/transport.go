@@ -0,0 +1,117 @@
+// Package httplog 提供记录 Provider HTTP 调用的 RoundTripper。
+package httplog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dq/kyc-sdk/internal/httpclient"
+	"github.com/dq/kyc-sdk/internal/redact"
+)
+
+// maxBodyLog 是日志中 body 的最大长度。
+const maxBodyLog = 4 << 10
+
+type Transport struct {
+	next     http.RoundTripper
+	logger   *slog.Logger
+	provider string
+	redactor *redact.Redactor
+}
+
+// NewTransport 包装 next（为 nil 时使用 http.DefaultTransport）：
+// 成功的调用记 Info，4xx / 5xx 与网络错误记 Warn；启用 Debug 时额外记录脱敏后的请求 / 响应 body。
+func NewTransport(next http.RoundTripper, logger *slog.Logger, provider string) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{next: next, logger: logger, provider: provider, redactor: redact.New(nil, nil)}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	debug := t.logger.Enabled(ctx, slog.LevelDebug)
+
+	var reqBody []byte
+	if debug && req.Body != nil && req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(io.LimitReader(rc, maxBodyLog))
+			rc.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	attrs := []slog.Attr{
+		slog.String("provider", t.provider),
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", httpclient.Attempt(ctx)),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if id := httpclient.RequestID(ctx); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", redact.Text(err.Error())))
+		t.logger.LogAttrs(ctx, slog.LevelWarn, "kyc-sdk: http request failed", attrs...)
+		return nil, err
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	failed := resp.StatusCode >= 400
+
+	// 只有需要记录 body 时才读取响应，读取后放回供调用方继续解析。
+	var respBody []byte
+	if failed || debug {
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		if err != nil {
+			return resp, nil
+		}
+	}
+
+	if id := correlationID(resp.Header, respBody); id != "" {
+		attrs = append(attrs, slog.String("correlation_id", id))
+	}
+	if debug && len(reqBody) > 0 {
+		attrs = append(attrs, slog.String("request_body", truncate(t.redactor.Body(req.Header.Get("Content-Type"), reqBody))))
+	}
+	if len(respBody) > 0 {
+		attrs = append(attrs, slog.String("response_body", truncate(t.redactor.Body(resp.Header.Get("Content-Type"), respBody))))
+	}
+
+	if failed {
+		t.logger.LogAttrs(ctx, slog.LevelWarn, "kyc-sdk: http request failed", attrs...)
+	} else {
+		t.logger.LogAttrs(ctx, slog.LevelInfo, "kyc-sdk: http request", attrs...)
+	}
+	return resp, nil
+}
+
+// correlationID 读取 Sumsub 的 correlation id：优先取响应 header，其次取错误 body 中的 correlationId。
+func correlationID(h http.Header, body []byte) string {
+	if id := h.Get("X-Correlation-Id"); id != "" {
+		return id
+	}
+	var v struct {
+		CorrelationID string `json:"correlationId"`
+	}
+	if json.Unmarshal(body, &v) == nil {
+		return v.CorrelationID
+	}
+	return ""
+}
+
+func truncate(s string) string {
+	if len(s) <= maxBodyLog {
+		return s
+	}
+	return s[:maxBodyLog] + "...(truncated)"
+}
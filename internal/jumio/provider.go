@@ -0,0 +1,350 @@
+package jumio
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/internal/httpclient"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+const defaultDatacenter = "amer-1"
+
+// Provider 是 Jumio 的实现。
+//
+// Jumio 的一次认证由 account 与 workflow execution 共同标识，
+// ApplicantID 的格式为 "<accountID>:<workflowExecutionID>"。
+type Provider struct {
+	cfg         config.JumioConfig
+	callbackURL string // 带 Basic Auth 凭据的回调地址
+	account     *httpclient.Client
+	retrieval   *httpclient.Client
+	tokens      *tokenSource
+}
+
+func New(cfg *config.Config) (*Provider, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: nil", kycerrors.ErrInvalidConfig)
+	}
+
+	jc := cfg.Jumio
+	if strings.TrimSpace(jc.ClientID) == "" {
+		return nil, fmt.Errorf("%w: Jumio.ClientID required", kycerrors.ErrInvalidConfig)
+	}
+	if strings.TrimSpace(jc.ClientSecret) == "" {
+		return nil, fmt.Errorf("%w: Jumio.ClientSecret required", kycerrors.ErrInvalidConfig)
+	}
+	if strings.TrimSpace(jc.Datacenter) == "" {
+		jc.Datacenter = defaultDatacenter
+	}
+	if strings.TrimSpace(jc.BaseURL) == "" {
+		jc.BaseURL = "https://account." + jc.Datacenter + ".jumio.ai"
+	}
+	if strings.TrimSpace(jc.AuthURL) == "" {
+		jc.AuthURL = "https://auth." + jc.Datacenter + ".jumio.ai"
+	}
+	if strings.TrimSpace(jc.RetrievalURL) == "" {
+		jc.RetrievalURL = "https://retrieval." + jc.Datacenter + ".jumio.ai"
+	}
+
+	if (jc.WebhookUsername == "") != (jc.WebhookPassword == "") {
+		return nil, fmt.Errorf("%w: Jumio.WebhookUsername and Jumio.WebhookPassword must be set together", kycerrors.ErrInvalidConfig)
+	}
+	callbackURL := jc.CallbackURL
+	if callbackURL != "" && jc.WebhookUsername != "" {
+		u, err := url.Parse(callbackURL)
+		if err != nil {
+			return nil, fmt.Errorf("%w: Jumio.CallbackURL: %v", kycerrors.ErrInvalidConfig, err)
+		}
+		u.User = url.UserPassword(jc.WebhookUsername, jc.WebhookPassword)
+		callbackURL = u.String()
+	}
+
+	return &Provider{
+		cfg:         jc,
+		callbackURL: callbackURL,
+		account:     httpclient.New(jc.BaseURL, cfg.TimeoutSec, httpclient.WithTransport(cfg.Transport), httpclient.WithRateLimiter(cfg.RateLimiter), httpclient.WithCircuitBreaker(cfg.CircuitBreaker)),
+		retrieval:   httpclient.New(jc.RetrievalURL, cfg.TimeoutSec, httpclient.WithTransport(cfg.Transport), httpclient.WithRateLimiter(cfg.RateLimiter), httpclient.WithCircuitBreaker(cfg.CircuitBreaker)),
+		tokens:      newTokenSource(httpclient.New(jc.AuthURL, cfg.TimeoutSec, httpclient.WithTransport(cfg.Transport), httpclient.WithCircuitBreaker(cfg.CircuitBreaker)), jc.ClientID, jc.ClientSecret),
+	}, nil
+}
+
+// authorized 携带 bearer token 调用 fn；token 被服务端拒绝（401）时刷新 token 并重试一次，
+// 重试请求的 ctx 通过 httpclient.WithAttempt 标记为下一次尝试。
+func (p *Provider) authorized(ctx context.Context, fn func(ctx context.Context, headers map[string]string) error) error {
+	first := httpclient.Attempt(ctx)
+	for attempt := 0; ; attempt++ {
+		token, err := p.tokens.Token(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = fn(httpclient.WithAttempt(ctx, first+attempt), map[string]string{"Authorization": "Bearer " + token})
+		var httpErr *kycerrors.HTTPError
+		if attempt == 0 && errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized {
+			p.tokens.Invalidate()
+			continue
+		}
+		return err
+	}
+}
+
+type workflowDefinition struct {
+	Key int `json:"key"`
+}
+
+type webConfig struct {
+	SuccessURL string `json:"successUrl,omitempty"`
+	ErrorURL   string `json:"errorUrl,omitempty"`
+}
+
+type accountRequest struct {
+	CustomerInternalReference string             `json:"customerInternalReference"`
+	UserReference             string             `json:"userReference"`
+	WorkflowDefinition        workflowDefinition `json:"workflowDefinition"`
+	CallbackURL               string             `json:"callbackUrl,omitempty"`
+	TokenLifetime             string             `json:"tokenLifetime,omitempty"`
+	Web                       *webConfig         `json:"web,omitempty"`
+}
+
+type accountDTO struct {
+	Account struct {
+		ID string `json:"id"`
+	} `json:"account"`
+	Web struct {
+		Href string `json:"href"`
+	} `json:"web"`
+	WorkflowExecution struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	} `json:"workflowExecution"`
+}
+
+func (p *Provider) CreateApplicant(ctx context.Context, userID string) (*model.ApplicantInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+
+	resp, err := p.createAccount(ctx, model.GenerateLinkRequest{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ApplicantInfo{
+		UserID:      userID,
+		ApplicantID: applicantID(resp.Account.ID, resp.WorkflowExecution.ID),
+		Status:      mapStatus(resp.WorkflowExecution.Status),
+		Result:      model.ResultNone,
+		Provider:    "jumio",
+	}, nil
+}
+
+type executionDTO struct {
+	Workflow struct {
+		ID                        string `json:"id"`
+		Status                    string `json:"status"`
+		CustomerInternalReference string `json:"customerInternalReference"`
+	} `json:"workflow"`
+	Decision struct {
+		Type string `json:"type"`
+	} `json:"decision"`
+}
+
+func (p *Provider) GetApplicant(ctx context.Context, applicantID string) (*model.ApplicantInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+
+	accountID, executionID, ok := strings.Cut(applicantID, ":")
+	if !ok || accountID == "" || executionID == "" {
+		return nil, errors.New("invalid applicant id, want <accountID>:<workflowExecutionID>")
+	}
+
+	path := "/api/v1/accounts/" + accountID + "/workflow-executions/" + executionID
+	var resp executionDTO
+	err := p.authorized(ctx, func(ctx context.Context, headers map[string]string) error {
+		return p.retrieval.GetJSON(ctx, path, headers, &resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ApplicantInfo{
+		UserID:      resp.Workflow.CustomerInternalReference,
+		ApplicantID: applicantID,
+		Status:      mapStatus(resp.Workflow.Status),
+		Result:      mapDecision(resp.Decision.Type),
+		Provider:    "jumio",
+	}, nil
+}
+
+// GenerateLink 创建 account 与 workflow execution，返回 Jumio 托管页面链接。
+// req.LevelName 对应 workflow definition key，为空时使用 JumioConfig.WorkflowKey。
+func (p *Provider) GenerateLink(ctx context.Context, req model.GenerateLinkRequest) (string, error) {
+	if p == nil {
+		return "", errors.New("nil provider")
+	}
+
+	resp, err := p.createAccount(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(resp.Web.Href) == "" {
+		return "", errors.New("empty link")
+	}
+	return resp.Web.Href, nil
+}
+
+func (p *Provider) createAccount(ctx context.Context, req model.GenerateLinkRequest) (*accountDTO, error) {
+	if strings.TrimSpace(req.UserID) == "" {
+		return nil, errors.New("missing user id")
+	}
+
+	key := p.cfg.WorkflowKey
+	if level := strings.TrimSpace(req.LevelName); level != "" {
+		k, err := strconv.Atoi(level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workflow key %q: %w", level, err)
+		}
+		key = k
+	}
+	if key == 0 {
+		return nil, errors.New("missing workflow key")
+	}
+
+	body := accountRequest{
+		CustomerInternalReference: req.UserID,
+		UserReference:             req.UserID,
+		WorkflowDefinition:        workflowDefinition{Key: key},
+		CallbackURL:               p.callbackURL,
+	}
+	if req.TTL > 0 {
+		body.TokenLifetime = strconv.Itoa(int(req.TTL)) + "s"
+	}
+	if strings.TrimSpace(req.SuccessURL) != "" || strings.TrimSpace(req.RejectURL) != "" {
+		body.Web = &webConfig{
+			SuccessURL: strings.TrimSpace(req.SuccessURL),
+			ErrorURL:   strings.TrimSpace(req.RejectURL),
+		}
+	}
+
+	var resp accountDTO
+	err := p.authorized(ctx, func(ctx context.Context, headers map[string]string) error {
+		return p.account.PostJSON(ctx, "/api/v1/accounts", body, headers, &resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Account.ID == "" {
+		return nil, errors.New("empty account id")
+	}
+	return &resp, nil
+}
+
+type callbackPayload struct {
+	// UserReference 是创建 account 时传入的业务侧用户标识。
+	UserReference     string `json:"userReference"`
+	WorkflowExecution struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	} `json:"workflowExecution"`
+	Account struct {
+		ID string `json:"id"`
+	} `json:"account"`
+}
+
+// WebhookSignatureHeader 返回回调认证使用的 header（Basic Auth），供 Router 识别回调来源。
+func (p *Provider) WebhookSignatureHeader() string {
+	return "Authorization"
+}
+
+// VerifyAndParseWebhook 校验并解析 Jumio 回调。
+//
+// Jumio 回调不带签名，只能通过回调地址中的 Basic Auth 凭据认证（JumioConfig.WebhookUsername /
+// WebhookPassword）：未配置凭据时返回 kycerrors.ErrInvalidConfig，不会接受任何回调；
+// 缺少或凭据不匹配时分别返回 ErrMissingSignature / ErrInvalidSignature。
+// 回调只携带状态不携带结论，业务侧应在收到 applicantReviewed 后调用 GetApplicant 拉取结论。
+func (p *Provider) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if p.cfg.WebhookUsername == "" || p.cfg.WebhookPassword == "" {
+		return nil, fmt.Errorf("%w: Jumio.WebhookUsername and Jumio.WebhookPassword required to authenticate callbacks", kycerrors.ErrInvalidConfig)
+	}
+
+	user, pass, ok := (&http.Request{Header: headers}).BasicAuth()
+	if !ok {
+		return nil, kycerrors.ErrMissingSignature
+	}
+	userOK := subtle.ConstantTimeCompare([]byte(user), []byte(p.cfg.WebhookUsername)) == 1
+	passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(p.cfg.WebhookPassword)) == 1
+	if !userOK || !passOK {
+		return nil, kycerrors.ErrInvalidSignature
+	}
+
+	in := callbackPayload{}
+	if err := json.Unmarshal(rawBody, &in); err != nil {
+		return nil, fmt.Errorf("parse webhook payload: %w", err)
+	}
+	if in.Account.ID == "" || in.WorkflowExecution.ID == "" {
+		return nil, errors.New("invalid callback: missing account or workflow execution id")
+	}
+
+	return &model.WebhookPayload{
+		Type:           string(mapEventType(in.WorkflowExecution.Status)),
+		ApplicantID:    applicantID(in.Account.ID, in.WorkflowExecution.ID),
+		ExternalUserID: in.UserReference,
+		ReviewStatus:   in.WorkflowExecution.Status,
+		ReviewResult:   model.ResultNone,
+	}, nil
+}
+
+func applicantID(accountID, executionID string) string {
+	return accountID + ":" + executionID
+}
+
+func mapEventType(status string) model.WebhookEventType {
+	switch status {
+	case "PROCESSED":
+		return model.EventApplicantReviewed
+	case "INITIATED":
+		return model.EventApplicantCreated
+	case "ACQUIRED":
+		return model.EventApplicantPending
+	default:
+		return model.WebhookEventType(status)
+	}
+}
+
+func mapStatus(s string) model.KycStatus {
+	switch s {
+	case "PROCESSED":
+		return model.StatusReviewed
+	case "INITIATED", "ACQUIRED":
+		return model.StatusPending
+	default:
+		return model.StatusUnknown
+	}
+}
+
+func mapDecision(s string) model.KycResult {
+	switch s {
+	case "PASSED":
+		return model.ResultGreen
+	case "REJECTED":
+		return model.ResultRed
+	case "WARNING":
+		return model.ResultYellow
+	default:
+		return model.ResultNone
+	}
+}
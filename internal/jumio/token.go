@@ -0,0 +1,111 @@
+package jumio
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dq/kyc-sdk/internal/httpclient"
+)
+
+// tokenExpirySkew 让 token 在真正过期前提前刷新，避免请求途中过期。
+const tokenExpirySkew = 60 * time.Second
+
+type tokenDTO struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+// tokenSource 通过 OAuth2 client-credentials 获取 access token，并在过期前复用。
+// 可被多个 goroutine 并发使用；并发的刷新合并为一次请求，且请求期间不持有锁，
+// 等待中的调用方可以随自己的 ctx 取消而返回。
+type tokenSource struct {
+	http         *httpclient.Client
+	clientID     string
+	clientSecret string
+	now          func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	inflight  *tokenFetch
+}
+
+// tokenFetch 是一次进行中的 token 请求，done 关闭后 token/err 可读。
+type tokenFetch struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+func newTokenSource(http *httpclient.Client, clientID, clientSecret string) *tokenSource {
+	return &tokenSource{
+		http:         http,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		now:          time.Now,
+	}
+}
+
+func (s *tokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	if s.token != "" && s.now().Before(s.expiresAt) {
+		token := s.token
+		s.mu.Unlock()
+		return token, nil
+	}
+	f := s.inflight
+	if f == nil {
+		f = &tokenFetch{done: make(chan struct{})}
+		s.inflight = f
+		// 请求不随发起方的 ctx 取消：其他调用方可能正在等待同一次刷新。
+		go s.fetch(context.WithoutCancel(ctx), f)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.token, f.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (s *tokenSource) fetch(ctx context.Context, f *tokenFetch) {
+	defer close(f.done)
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	headers := map[string]string{
+		"Authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte(s.clientID+":"+s.clientSecret)),
+	}
+
+	var resp tokenDTO
+	err := s.http.PostForm(ctx, "/oauth2/token", form, headers, &resp)
+	if err == nil && resp.AccessToken == "" {
+		err = errors.New("empty access token")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inflight = nil
+	if err != nil {
+		f.err = err
+		return
+	}
+	f.token = resp.AccessToken
+	s.token = resp.AccessToken
+	s.expiresAt = s.now().Add(time.Duration(resp.ExpiresIn)*time.Second - tokenExpirySkew)
+}
+
+// Invalidate 丢弃缓存的 token，下一次 Token 调用会重新获取。
+func (s *tokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = ""
+	s.expiresAt = time.Time{}
+}
@@ -0,0 +1,111 @@
+package jumio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dq/kyc-sdk/internal/httpclient"
+)
+
+func TestTokenSource_CachesUntilExpiry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth2/token" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "id" || pass != "secret" {
+			t.Fatalf("unexpected basic auth: %s/%s", user, pass)
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			t.Fatalf("unexpected form: %v", r.PostForm)
+		}
+		n := atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"access_token":"tok-` + strconv.Itoa(int(n)) + `","expires_in":3600,"token_type":"Bearer"}`))
+	}))
+	defer srv.Close()
+
+	now := time.Unix(0, 0)
+	s := newTokenSource(httpclient.New(srv.URL, 1), "id", "secret")
+	s.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		tok, err := s.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token: %v", err)
+		}
+		if tok != "tok-1" {
+			t.Fatalf("expected cached token, got %s", tok)
+		}
+	}
+
+	// 进入提前刷新窗口后应重新获取。
+	now = now.Add(time.Hour - tokenExpirySkew)
+	tok, err := s.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if tok != "tok-2" || atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("expected refreshed token, got %s after %d calls", tok, calls)
+	}
+
+	s.Invalidate()
+	if tok, _ := s.Token(context.Background()); tok != "tok-3" {
+		t.Fatalf("expected token after invalidate, got %s", tok)
+	}
+}
+
+func TestTokenSource_WaiterReturnsOnCancel(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		_, _ = w.Write([]byte(`{"access_token":"tok","expires_in":3600,"token_type":"Bearer"}`))
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	s := newTokenSource(httpclient.New(srv.URL, 1), "id", "secret")
+
+	// 第一个调用方发起慢请求。
+	first := make(chan error, 1)
+	go func() {
+		_, err := s.Token(context.Background())
+		first <- err
+	}()
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// 等待同一次刷新的调用方应在自己的 ctx 取消后立即返回。
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.Token(ctx)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter blocked after its ctx was cancelled")
+	}
+
+	release <- struct{}{}
+	if err := <-first; err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("expected a single token request, got %d", n)
+	}
+}
@@ -0,0 +1,49 @@
+package onfido
+
+import "sync"
+
+// maxKnownApplicants 是进程内记住的 userID → applicant ID 映射数量上限，超出后淘汰最早的记录。
+const maxKnownApplicants = 10000
+
+// knownApplicants 记录本进程为各用户创建的 applicant，使同一用户重复 GenerateLink（例如重试、链接过期）
+// 时复用同一个 applicant，而不是每次新建。Onfido 无法按外部用户 ID 查询 applicant，
+// 跨进程 / 重启后的复用需要调用方保存 applicant ID 并通过 GenerateLinkRequest.ApplicantID 传入。
+type knownApplicants struct {
+	mu     sync.Mutex
+	byUser map[string]string
+	order  []string
+	next   int
+}
+
+func newKnownApplicants(size int) *knownApplicants {
+	return &knownApplicants{
+		byUser: make(map[string]string),
+		order:  make([]string, 0, size),
+	}
+}
+
+func (k *knownApplicants) get(userID string) (string, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	id, ok := k.byUser[userID]
+	return id, ok
+}
+
+// add 记录 userID 的 applicant；已有记录时保留原值并返回它。
+func (k *knownApplicants) add(userID, applicantID string) string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if id, ok := k.byUser[userID]; ok {
+		return id
+	}
+
+	if len(k.order) < cap(k.order) {
+		k.order = append(k.order, userID)
+	} else {
+		delete(k.byUser, k.order[k.next])
+		k.order[k.next] = userID
+		k.next = (k.next + 1) % len(k.order)
+	}
+	k.byUser[userID] = applicantID
+	return applicantID
+}
@@ -0,0 +1,381 @@
+package onfido
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/internal/httpclient"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+const (
+	defaultBaseURL = "https://api.eu.onfido.com"
+	apiPrefix      = "/v3.6"
+)
+
+type Provider struct {
+	cfg        config.OnfidoConfig
+	http       *httpclient.Client
+	now        func() time.Time
+	applicants *knownApplicants
+}
+
+func New(cfg *config.Config) (*Provider, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: nil", kycerrors.ErrInvalidConfig)
+	}
+
+	oc := cfg.Onfido
+	if strings.TrimSpace(oc.APIToken) == "" {
+		return nil, fmt.Errorf("%w: Onfido.APIToken required", kycerrors.ErrInvalidConfig)
+	}
+	if strings.TrimSpace(oc.BaseURL) == "" {
+		oc.BaseURL = defaultBaseURL
+	}
+
+	return &Provider{
+		cfg:        oc,
+		http:       httpclient.New(oc.BaseURL, cfg.TimeoutSec, httpclient.WithTransport(cfg.Transport), httpclient.WithRateLimiter(cfg.RateLimiter), httpclient.WithCircuitBreaker(cfg.CircuitBreaker)),
+		now:        time.Now,
+		applicants: newKnownApplicants(maxKnownApplicants),
+	}, nil
+}
+
+func (p *Provider) headers() map[string]string {
+	return map[string]string{
+		"Authorization": "Token token=" + p.cfg.APIToken,
+	}
+}
+
+type applicantRequest struct {
+	Email       string `json:"email,omitempty"`
+	PhoneNumber string `json:"phone_number,omitempty"`
+}
+
+type applicantDTO struct {
+	ID string `json:"id"`
+}
+
+type checkDTO struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Result string `json:"result"`
+}
+
+type checksDTO struct {
+	Checks []checkDTO `json:"checks"`
+}
+
+// CreateApplicant 创建 Onfido applicant。Onfido 不保存外部用户 ID，
+// userID 会在 GenerateLink 时作为 workflow run 的 customer_user_id 传入。
+// 本进程已为该用户创建过 applicant 时直接返回它，GenerateLink 也会复用它。
+func (p *Provider) CreateApplicant(ctx context.Context, userID string) (*model.ApplicantInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(userID) == "" {
+		return nil, errors.New("missing user id")
+	}
+
+	applicantID, err := p.applicantFor(ctx, userID, applicantRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ApplicantInfo{
+		UserID:      userID,
+		ApplicantID: applicantID,
+		Status:      model.StatusPending,
+		Result:      model.ResultNone,
+		Provider:    "onfido",
+	}, nil
+}
+
+// GetApplicant 以 applicant 最近一次 check 的状态作为审核结果。
+func (p *Provider) GetApplicant(ctx context.Context, applicantID string) (*model.ApplicantInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(applicantID) == "" {
+		return nil, errors.New("missing applicant id")
+	}
+
+	path := apiPrefix + "/checks?applicant_id=" + url.QueryEscape(applicantID)
+	var resp checksDTO
+	if err := p.http.GetJSON(ctx, path, p.headers(), &resp); err != nil {
+		return nil, err
+	}
+
+	info := &model.ApplicantInfo{
+		ApplicantID: applicantID,
+		Status:      model.StatusPending,
+		Result:      model.ResultNone,
+		Provider:    "onfido",
+	}
+	if len(resp.Checks) > 0 {
+		latest := resp.Checks[0]
+		info.Status = mapCheckStatus(latest.Status)
+		info.Result = mapCheckResult(latest.Result)
+	}
+	return info, nil
+}
+
+type workflowRunLink struct {
+	CompletedRedirectURL string `json:"completed_redirect_url,omitempty"`
+	ExpiredRedirectURL   string `json:"expired_redirect_url,omitempty"`
+	ExpiresAt            string `json:"expires_at,omitempty"`
+}
+
+type workflowRunRequest struct {
+	WorkflowID     string           `json:"workflow_id"`
+	ApplicantID    string           `json:"applicant_id"`
+	CustomerUserID string           `json:"customer_user_id,omitempty"`
+	Link           *workflowRunLink `json:"link,omitempty"`
+}
+
+type workflowRunDTO struct {
+	ID   string `json:"id"`
+	Link struct {
+		URL string `json:"url"`
+	} `json:"link"`
+}
+
+// GenerateLink 为用户创建 workflow run，返回 Onfido 托管页面链接。
+// applicant 依次取 req.ApplicantID、本进程为该用户创建过的 applicant，都没有时才新建。
+// req.LevelName 对应 Onfido workflow ID，为空时使用 OnfidoConfig.WorkflowID。
+func (p *Provider) GenerateLink(ctx context.Context, req model.GenerateLinkRequest) (string, error) {
+	if p == nil {
+		return "", errors.New("nil provider")
+	}
+	if strings.TrimSpace(req.UserID) == "" {
+		return "", errors.New("missing user id")
+	}
+
+	workflowID := strings.TrimSpace(req.LevelName)
+	if workflowID == "" {
+		workflowID = p.cfg.WorkflowID
+	}
+	if workflowID == "" {
+		return "", errors.New("missing workflow id")
+	}
+
+	applicantID := strings.TrimSpace(req.ApplicantID)
+	if applicantID == "" {
+		var err error
+		applicantID, err = p.applicantFor(ctx, req.UserID, applicantRequest{
+			Email:       strings.TrimSpace(req.Email),
+			PhoneNumber: strings.TrimSpace(req.Phone),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ttl := req.TTL
+	if ttl <= 0 {
+		ttl = 1800
+	}
+
+	body := workflowRunRequest{
+		WorkflowID:     workflowID,
+		ApplicantID:    applicantID,
+		CustomerUserID: req.UserID,
+		Link: &workflowRunLink{
+			CompletedRedirectURL: strings.TrimSpace(req.SuccessURL),
+			ExpiredRedirectURL:   strings.TrimSpace(req.RejectURL),
+			ExpiresAt:            p.now().Add(time.Duration(ttl) * time.Second).UTC().Format(time.RFC3339),
+		},
+	}
+
+	var resp workflowRunDTO
+	if err := p.http.PostJSON(ctx, apiPrefix+"/workflow_runs", body, p.headers(), &resp); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(resp.Link.URL) == "" {
+		return "", errors.New("empty link")
+	}
+	return resp.Link.URL, nil
+}
+
+// applicantFor 返回本进程为 userID 创建过的 applicant，没有时新建并记录。
+func (p *Provider) applicantFor(ctx context.Context, userID string, body applicantRequest) (string, error) {
+	if id, ok := p.applicants.get(userID); ok {
+		return id, nil
+	}
+	applicant, err := p.createApplicant(ctx, body)
+	if err != nil {
+		return "", err
+	}
+	// 并发创建时以先记录的为准，多出的 applicant 不会被使用。
+	return p.applicants.add(userID, applicant.ID), nil
+}
+
+func (p *Provider) createApplicant(ctx context.Context, body applicantRequest) (*applicantDTO, error) {
+	var resp applicantDTO
+	if err := p.http.PostJSON(ctx, apiPrefix+"/applicants", body, p.headers(), &resp); err != nil {
+		return nil, err
+	}
+	if resp.ID == "" {
+		return nil, errors.New("empty applicant id")
+	}
+	return &resp, nil
+}
+
+// webhookPayload 是 Onfido 回调：object 只包含资源的 id、status、完成时间与 href，
+// applicant 与审核结果需要再查询对应资源。
+type webhookPayload struct {
+	Payload struct {
+		// ResourceType 是事件对应的资源类型，例如 check、workflow_run。
+		ResourceType string `json:"resource_type"`
+		// Action 是事件类型，例如 check.completed、workflow_run.completed。
+		Action string `json:"action"`
+		Object struct {
+			ID                 string `json:"id"`
+			Status             string `json:"status"`
+			CompletedAtISO8601 string `json:"completed_at_iso8601"`
+			Href               string `json:"href"`
+		} `json:"object"`
+	} `json:"payload"`
+}
+
+type webhookCheckDTO struct {
+	ID          string `json:"id"`
+	ApplicantID string `json:"applicant_id"`
+	Status      string `json:"status"`
+	Result      string `json:"result"`
+}
+
+type webhookWorkflowRunDTO struct {
+	ID             string `json:"id"`
+	ApplicantID    string `json:"applicant_id"`
+	CustomerUserID string `json:"customer_user_id"`
+	Status         string `json:"status"`
+}
+
+// signatureHeader 是 Onfido Webhook 携带签名的 header。
+const signatureHeader = "X-SHA2-Signature"
+
+// WebhookSignatureHeader 返回 Webhook 签名 header，供 Router 识别回调来源。
+func (p *Provider) WebhookSignatureHeader() string {
+	return signatureHeader
+}
+
+func (p *Provider) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	return p.VerifyAndParseWebhookContext(context.Background(), headers, rawBody)
+}
+
+// VerifyAndParseWebhookContext 验签并解析回调。check 与 workflow_run 事件会按 object.id 查询对应资源，
+// 补全 applicant ID、外部用户 ID（仅 workflow run）与审核结果；查询使用 ctx。
+func (p *Provider) VerifyAndParseWebhookContext(ctx context.Context, headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(p.cfg.WebhookToken) == "" {
+		return nil, fmt.Errorf("%w: Onfido.WebhookToken required", kycerrors.ErrInvalidConfig)
+	}
+
+	sig := strings.TrimSpace(headers.Get(signatureHeader))
+	if sig == "" {
+		return nil, kycerrors.ErrMissingSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(p.cfg.WebhookToken))
+	mac.Write(rawBody)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(sig))) {
+		return nil, kycerrors.ErrInvalidSignature
+	}
+
+	in := webhookPayload{}
+	if err := json.Unmarshal(rawBody, &in); err != nil {
+		return nil, fmt.Errorf("parse webhook payload: %w", err)
+	}
+
+	obj := in.Payload.Object
+	out := &model.WebhookPayload{
+		Type:         string(mapEventType(in.Payload.Action)),
+		ReviewStatus: obj.Status,
+		ReviewResult: model.ResultNone,
+	}
+	if obj.ID == "" {
+		return out, nil
+	}
+
+	// 只按 ID 查询配置的 BaseURL，不跟随回调中的 href，避免请求被引向其他主机。
+	switch in.Payload.ResourceType {
+	case "check":
+		var check webhookCheckDTO
+		if err := p.http.GetJSON(ctx, apiPrefix+"/checks/"+url.PathEscape(obj.ID), p.headers(), &check); err != nil {
+			return nil, fmt.Errorf("fetch check %s: %w", obj.ID, err)
+		}
+		out.ApplicantID = check.ApplicantID
+		out.ReviewResult = mapCheckResult(check.Result)
+	case "workflow_run":
+		var run webhookWorkflowRunDTO
+		if err := p.http.GetJSON(ctx, apiPrefix+"/workflow_runs/"+url.PathEscape(obj.ID), p.headers(), &run); err != nil {
+			return nil, fmt.Errorf("fetch workflow run %s: %w", obj.ID, err)
+		}
+		out.ApplicantID = run.ApplicantID
+		out.ExternalUserID = run.CustomerUserID
+		out.ReviewResult = mapWorkflowRunResult(run.Status)
+	}
+	return out, nil
+}
+
+// mapEventType 把 Onfido 事件映射为统一事件类型，无法映射的事件保留原始 action。
+func mapEventType(action string) model.WebhookEventType {
+	switch action {
+	case "check.completed", "workflow_run.completed":
+		return model.EventApplicantReviewed
+	case "check.started", "workflow_run.started":
+		return model.EventApplicantPending
+	default:
+		return model.WebhookEventType(action)
+	}
+}
+
+func mapCheckStatus(s string) model.KycStatus {
+	switch s {
+	case "complete":
+		return model.StatusReviewed
+	case "in_progress", "awaiting_applicant", "paused", "reopened":
+		return model.StatusPending
+	default:
+		return model.StatusUnknown
+	}
+}
+
+func mapCheckResult(s string) model.KycResult {
+	switch s {
+	case "clear":
+		return model.ResultGreen
+	case "consider":
+		return model.ResultYellow
+	default:
+		return model.ResultNone
+	}
+}
+
+func mapWorkflowRunResult(s string) model.KycResult {
+	switch s {
+	case "approved":
+		return model.ResultGreen
+	case "declined":
+		return model.ResultRed
+	case "review":
+		return model.ResultYellow
+	default:
+		return model.ResultNone
+	}
+}
@@ -0,0 +1,193 @@
+package persona
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/internal/httpclient"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+const (
+	defaultBaseURL       = "https://withpersona.com"
+	defaultHostedFlowURL = "https://withpersona.com/verify"
+	defaultToleranceSec  = 300
+	apiVersion           = "2023-01-05"
+)
+
+// Provider 是 Persona 的实现，一个 inquiry 对应一个 applicant。
+type Provider struct {
+	cfg  config.PersonaConfig
+	http *httpclient.Client
+	now  func() time.Time
+}
+
+func New(cfg *config.Config) (*Provider, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: nil", kycerrors.ErrInvalidConfig)
+	}
+
+	pc := cfg.Persona
+	if strings.TrimSpace(pc.APIKey) == "" {
+		return nil, fmt.Errorf("%w: Persona.APIKey required", kycerrors.ErrInvalidConfig)
+	}
+	if strings.TrimSpace(pc.BaseURL) == "" {
+		pc.BaseURL = defaultBaseURL
+	}
+	if strings.TrimSpace(pc.HostedFlowURL) == "" {
+		pc.HostedFlowURL = defaultHostedFlowURL
+	}
+	if pc.WebhookToleranceSec <= 0 {
+		pc.WebhookToleranceSec = defaultToleranceSec
+	}
+
+	return &Provider{
+		cfg:  pc,
+		http: httpclient.New(pc.BaseURL, cfg.TimeoutSec, httpclient.WithTransport(cfg.Transport), httpclient.WithRateLimiter(cfg.RateLimiter), httpclient.WithCircuitBreaker(cfg.CircuitBreaker)),
+		now:  time.Now,
+	}, nil
+}
+
+func (p *Provider) headers() map[string]string {
+	return map[string]string{
+		"Authorization":   "Bearer " + p.cfg.APIKey,
+		"Persona-Version": apiVersion,
+	}
+}
+
+type inquiryAttributes struct {
+	Status            string `json:"status,omitempty"`
+	ReferenceID       string `json:"reference-id,omitempty"`
+	InquiryTemplateID string `json:"inquiry-template-id,omitempty"`
+}
+
+type inquiryData struct {
+	Type       string            `json:"type,omitempty"`
+	ID         string            `json:"id,omitempty"`
+	Attributes inquiryAttributes `json:"attributes"`
+}
+
+type inquiryDTO struct {
+	Data inquiryData `json:"data"`
+}
+
+func (p *Provider) CreateApplicant(ctx context.Context, userID string) (*model.ApplicantInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(userID) == "" {
+		return nil, errors.New("missing user id")
+	}
+	if strings.TrimSpace(p.cfg.TemplateID) == "" {
+		return nil, errors.New("missing inquiry template id")
+	}
+
+	body := inquiryDTO{Data: inquiryData{Attributes: inquiryAttributes{
+		InquiryTemplateID: p.cfg.TemplateID,
+		ReferenceID:       userID,
+	}}}
+
+	var resp inquiryDTO
+	if err := p.http.PostJSON(ctx, "/api/v1/inquiries", body, p.headers(), &resp); err != nil {
+		return nil, err
+	}
+	if resp.Data.ID == "" {
+		return nil, errors.New("empty inquiry id")
+	}
+	return mapInquiry(resp.Data), nil
+}
+
+func (p *Provider) GetApplicant(ctx context.Context, applicantID string) (*model.ApplicantInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(applicantID) == "" {
+		return nil, errors.New("missing applicant id")
+	}
+
+	var resp inquiryDTO
+	if err := p.http.GetJSON(ctx, "/api/v1/inquiries/"+applicantID, p.headers(), &resp); err != nil {
+		return nil, err
+	}
+	return mapInquiry(resp.Data), nil
+}
+
+// GenerateLink 生成 Persona 托管页面链接，以 reference-id 关联业务用户，
+// Persona 会在用户打开链接时创建（或复用）inquiry，无需预先调用 API。
+// req.LevelName 对应 inquiry template ID，为空时使用 PersonaConfig.TemplateID。
+func (p *Provider) GenerateLink(ctx context.Context, req model.GenerateLinkRequest) (string, error) {
+	if p == nil {
+		return "", errors.New("nil provider")
+	}
+	if strings.TrimSpace(req.UserID) == "" {
+		return "", errors.New("missing user id")
+	}
+
+	templateID := strings.TrimSpace(req.LevelName)
+	if templateID == "" {
+		templateID = p.cfg.TemplateID
+	}
+	if templateID == "" {
+		return "", errors.New("missing inquiry template id")
+	}
+
+	q := url.Values{}
+	q.Set("inquiry-template-id", templateID)
+	q.Set("reference-id", req.UserID)
+	if p.cfg.EnvironmentID != "" {
+		q.Set("environment-id", p.cfg.EnvironmentID)
+	}
+	if v := strings.TrimSpace(req.SuccessURL); v != "" {
+		q.Set("redirect-uri", v)
+	}
+	if v := strings.TrimSpace(req.Email); v != "" {
+		q.Set("fields[email-address]", v)
+	}
+	if v := strings.TrimSpace(req.Phone); v != "" {
+		q.Set("fields[phone-number]", v)
+	}
+
+	return strings.TrimRight(p.cfg.HostedFlowURL, "?") + "?" + q.Encode(), nil
+}
+
+func mapInquiry(data inquiryData) *model.ApplicantInfo {
+	return &model.ApplicantInfo{
+		UserID:      data.Attributes.ReferenceID,
+		ApplicantID: data.ID,
+		Status:      mapStatus(data.Attributes.Status),
+		Result:      mapResult(data.Attributes.Status),
+		Provider:    "persona",
+	}
+}
+
+// mapStatus 映射 inquiry 状态。completed 表示用户已通过全部校验；
+// 开启 decisioning 时最终结论以 approved / declined 为准。
+func mapStatus(s string) model.KycStatus {
+	switch s {
+	case "created", "pending", "needs_review":
+		return model.StatusPending
+	case "completed", "approved", "declined", "failed":
+		return model.StatusReviewed
+	default:
+		return model.StatusUnknown
+	}
+}
+
+func mapResult(s string) model.KycResult {
+	switch s {
+	case "completed", "approved":
+		return model.ResultGreen
+	case "declined", "failed":
+		return model.ResultRed
+	case "needs_review":
+		return model.ResultYellow
+	default:
+		return model.ResultNone
+	}
+}
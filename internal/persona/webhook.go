@@ -0,0 +1,140 @@
+package persona
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+type webhookPayload struct {
+	Data struct {
+		Attributes struct {
+			// Name 是事件名称，例如 inquiry.completed、inquiry.approved。
+			Name    string `json:"name"`
+			Payload struct {
+				Data inquiryData `json:"data"`
+			} `json:"payload"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// signatureHeader 是 Persona Webhook 携带签名的 header。
+const signatureHeader = "Persona-Signature"
+
+// WebhookSignatureHeader 返回 Webhook 签名 header，供 Router 识别回调来源。
+func (p *Provider) WebhookSignatureHeader() string {
+	return signatureHeader
+}
+
+// VerifyAndParseWebhook 校验 Persona-Signature 并解析 inquiry 事件。
+//
+// Persona-Signature 形如 "t=<unix>,v1=<hex>"；轮换 secret 期间会携带多组签名（以空格分隔），
+// 任一组匹配即视为通过。时间戳超出 WebhookToleranceSec 的回调会被拒绝以防重放。
+func (p *Provider) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(p.cfg.WebhookSecret) == "" {
+		return nil, fmt.Errorf("%w: Persona.WebhookSecret required", kycerrors.ErrInvalidConfig)
+	}
+
+	header := strings.TrimSpace(headers.Get(signatureHeader))
+	if header == "" {
+		return nil, kycerrors.ErrMissingSignature
+	}
+	if err := p.verifySignature(header, rawBody); err != nil {
+		return nil, err
+	}
+
+	in := webhookPayload{}
+	if err := json.Unmarshal(rawBody, &in); err != nil {
+		return nil, fmt.Errorf("parse webhook payload: %w", err)
+	}
+
+	inquiry := in.Data.Attributes.Payload.Data
+	return &model.WebhookPayload{
+		Type:           string(mapEventType(in.Data.Attributes.Name)),
+		ApplicantID:    inquiry.ID,
+		ExternalUserID: inquiry.Attributes.ReferenceID,
+		ReviewStatus:   inquiry.Attributes.Status,
+		ReviewResult:   mapResult(inquiry.Attributes.Status),
+	}, nil
+}
+
+func (p *Provider) verifySignature(header string, rawBody []byte) error {
+	tolerance := time.Duration(p.cfg.WebhookToleranceSec) * time.Second
+	expired := false
+
+	for _, group := range strings.Fields(header) {
+		ts, sigs := parseSignatureGroup(group)
+		if ts == "" || len(sigs) == 0 {
+			continue
+		}
+
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			continue
+		}
+		if d := p.now().Sub(time.Unix(sec, 0)); d > tolerance || d < -tolerance {
+			expired = true
+			continue
+		}
+
+		mac := hmac.New(sha256.New, []byte(p.cfg.WebhookSecret))
+		mac.Write([]byte(ts + "."))
+		mac.Write(rawBody)
+		expected := []byte(hex.EncodeToString(mac.Sum(nil)))
+		for _, sig := range sigs {
+			if hmac.Equal(expected, []byte(sig)) {
+				return nil
+			}
+		}
+	}
+
+	if expired {
+		return fmt.Errorf("%w: timestamp outside tolerance", kycerrors.ErrInvalidSignature)
+	}
+	return kycerrors.ErrInvalidSignature
+}
+
+// parseSignatureGroup 解析 "t=<unix>,v1=<hex>[,v1=<hex>...]"。
+func parseSignatureGroup(group string) (string, []string) {
+	var ts string
+	var sigs []string
+	for _, part := range strings.Split(group, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sigs = append(sigs, strings.ToLower(v))
+		}
+	}
+	return ts, sigs
+}
+
+func mapEventType(name string) model.WebhookEventType {
+	switch name {
+	case "inquiry.created":
+		return model.EventApplicantCreated
+	case "inquiry.started", "inquiry.marked-for-review":
+		return model.EventApplicantPending
+	case "inquiry.completed", "inquiry.approved", "inquiry.declined", "inquiry.failed":
+		return model.EventApplicantReviewed
+	default:
+		return model.WebhookEventType(name)
+	}
+}
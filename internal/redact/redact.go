@@ -0,0 +1,194 @@
+// Package redact 对 header 与 body 中的凭证和 PII 做脱敏，供日志与录制 / 回放使用。
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Placeholder 是脱敏后的占位值。
+const Placeholder = "REDACTED"
+
+// DefaultHeaders 是默认脱敏的 header。
+var DefaultHeaders = []string{
+	"Authorization",
+	"X-App-Token",
+	"X-App-Access-Sig",
+	"X-App-Access-Ts",
+	"X-Auth-Client",
+	"X-Hmac-Signature",
+	"X-Payload-Digest",
+	"X-Sha2-Signature",
+	"Persona-Signature",
+}
+
+// DefaultFields 是默认脱敏的 JSON / 表单字段（按字段名匹配，忽略大小写，任意层级）。
+// 形如 "parent.field" 的条目只匹配父字段（数组元素取数组字段名）为 parent 的 field，用于 number 这类过于通用的字段名。
+var DefaultFields = []string{
+	// 个人信息
+	"email", "phone", "phoneNumber", "phone_number", "firstName", "lastName", "middleName", "fullName", "name-first", "name-last",
+	"dob", "placeOfBirth", "address", "street", "postCode", "idDocNumber", "idDocs.number", "tin",
+	// 企业（KYB）
+	"companyName", "legalAddress", "registrationNumber",
+	// 交易（KYT / Travel Rule）：IP、账户与钱包地址
+	"ip", "accountId", "walletAddress",
+	// 凭证
+	"client_secret", "access_token", "token", "signature", "callbackUrl",
+}
+
+// DefaultURIFields 是 URL 中额外脱敏的查询参数与路径参数（例如 Sumsub 的 -;externalUserId=<id>）：
+// 它们在 body 中是正常的关联字段，但写进 URL 后会随录制文件提交。
+var DefaultURIFields = []string{"externalUserId", "levelName", "applicant_id", "applicantId"}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`\+?\d[\d \-]{7,}\d`)
+)
+
+type Redactor struct {
+	headers   map[string]bool
+	fields    map[string]bool
+	uriFields map[string]bool
+}
+
+// New 创建 Redactor，headers / fields 为 nil 时使用默认列表。
+func New(headers, fields []string) *Redactor {
+	if headers == nil {
+		headers = DefaultHeaders
+	}
+	if fields == nil {
+		fields = DefaultFields
+	}
+
+	r := &Redactor{headers: make(map[string]bool), fields: make(map[string]bool), uriFields: make(map[string]bool)}
+	for _, h := range headers {
+		r.headers[http.CanonicalHeaderKey(h)] = true
+	}
+	for _, f := range fields {
+		r.fields[strings.ToLower(f)] = true
+	}
+	for _, f := range DefaultURIFields {
+		r.uriFields[strings.ToLower(f)] = true
+	}
+	return r
+}
+
+// Field 判断字段名是否需要脱敏（不考虑父字段）。
+func (r *Redactor) Field(name string) bool {
+	return r.fields[strings.ToLower(name)]
+}
+
+// field 判断父字段 parent 下的字段 name 是否需要脱敏。
+func (r *Redactor) field(parent, name string) bool {
+	return r.Field(name) || (parent != "" && r.fields[strings.ToLower(parent+"."+name)])
+}
+
+func (r *Redactor) Headers(h http.Header) http.Header {
+	out := h.Clone()
+	for k := range out {
+		if r.headers[http.CanonicalHeaderKey(k)] {
+			out[k] = []string{Placeholder}
+		}
+	}
+	return out
+}
+
+// URI 对请求 URI（path 与 query）脱敏：query 参数与路径中 ;key=value 形式的参数按字段名匹配，
+// 匹配 fields 或 DefaultURIFields 时替换值。query 会按参数名重新排序。
+func (r *Redactor) URI(uri string) string {
+	path, query, hasQuery := strings.Cut(uri, "?")
+
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		params := strings.Split(seg, ";")
+		for j := 1; j < len(params); j++ {
+			if k, _, ok := strings.Cut(params[j], "="); ok && r.uriField(k) {
+				params[j] = k + "=" + Placeholder
+			}
+		}
+		segments[i] = strings.Join(params, ";")
+	}
+	out := strings.Join(segments, "/")
+	if !hasQuery {
+		return out
+	}
+
+	q, err := url.ParseQuery(query)
+	if err != nil {
+		return out + "?" + Text(query)
+	}
+	for k := range q {
+		if r.uriField(k) {
+			q[k] = []string{Placeholder}
+		}
+	}
+	return out + "?" + q.Encode()
+}
+
+func (r *Redactor) uriField(name string) bool {
+	return r.Field(name) || r.uriFields[strings.ToLower(name)]
+}
+
+// Body 对 JSON 与表单 body 做字段脱敏，JSON 会被重新序列化以获得稳定的比较结果；
+// 其他格式按文本处理，替换其中的邮箱与手机号。
+func (r *Redactor) Body(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err == nil {
+			for k := range form {
+				if r.Field(k) {
+					form[k] = []string{Placeholder}
+				}
+			}
+			return form.Encode()
+		}
+	}
+
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		bs, err := json.Marshal(r.value("", v))
+		if err == nil {
+			return string(bs)
+		}
+	}
+	return Text(string(body))
+}
+
+// Text 替换自由文本中的邮箱与手机号。
+func Text(s string) string {
+	s = emailPattern.ReplaceAllString(s, Placeholder)
+	return phonePattern.ReplaceAllString(s, Placeholder)
+}
+
+// value 递归脱敏 v，parent 是 v 所在的字段名（数组元素沿用数组的字段名）。
+func (r *Redactor) value(parent string, v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			// 敏感字段整体替换，值为对象或数组时也不保留其结构。
+			if r.field(parent, k) {
+				t[k] = Placeholder
+				continue
+			}
+			t[k] = r.value(k, child)
+		}
+	case []any:
+		for i, child := range t {
+			t[i] = r.value(parent, child)
+		}
+	case string:
+		// JSON 字符串里常有纯数字的 ID / 时间戳，这里只替换邮箱。
+		return emailPattern.ReplaceAllString(t, Placeholder)
+	}
+	return v
+}
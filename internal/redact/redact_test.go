@@ -0,0 +1,113 @@
+package redact
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactor_Body(t *testing.T) {
+	r := New(nil, nil)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{
+			name:        "personal info",
+			contentType: "application/json",
+			body:        `{"externalUserId":"u1","info":{"firstName":"Ann","dob":"1990-01-01","phone_number":"+4912345678"}}`,
+			want:        `{"externalUserId":"u1","info":{"dob":"REDACTED","firstName":"REDACTED","phone_number":"REDACTED"}}`,
+		},
+		{
+			name:        "company (KYB)",
+			contentType: "application/json",
+			body:        `{"type":"company","info":{"companyInfo":{"companyName":"ACME","registrationNumber":"HRB 1","legalAddress":"Main St 1","country":"DEU"}}}`,
+			want:        `{"info":{"companyInfo":{"companyName":"REDACTED","country":"DEU","legalAddress":"REDACTED","registrationNumber":"REDACTED"}},"type":"company"}`,
+		},
+		{
+			name:        "transaction (KYT)",
+			contentType: "application/json",
+			body:        `{"txnId":"t1","info":{"amount":10},"applicant":{"device":{"ipInfo":{"ip":"1.2.3.4"}},"paymentMethod":{"type":"crypto","accountId":"0xabc"}}}`,
+			want:        `{"applicant":{"device":{"ipInfo":{"ip":"REDACTED"}},"paymentMethod":{"accountId":"REDACTED","type":"crypto"}},"info":{"amount":10},"txnId":"t1"}`,
+		},
+		{
+			name:        "number only under idDocs",
+			contentType: "application/json",
+			body:        `{"idDocs":[{"idDocType":"PASSPORT","number":"X123"}],"page":{"number":2}}`,
+			want:        `{"idDocs":[{"idDocType":"PASSPORT","number":"REDACTED"}],"page":{"number":2}}`,
+		},
+		{
+			name:        "sensitive object",
+			contentType: "application/json",
+			body:        `{"address":{"street":"Main St 1","town":"Berlin"},"country":"DEU"}`,
+			want:        `{"address":"REDACTED","country":"DEU"}`,
+		},
+		{
+			name:        "callback url with credentials",
+			contentType: "application/json",
+			body:        `{"callbackUrl":"https://user:pw@example.com/jumio"}`,
+			want:        `{"callbackUrl":"REDACTED"}`,
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "grant_type=client_credentials&client_secret=s3cr3t",
+			want:        "client_secret=REDACTED&grant_type=client_credentials",
+		},
+		{
+			name:        "text",
+			contentType: "text/plain",
+			body:        "contact ann@example.com or +49 123 456 789",
+			want:        "contact REDACTED or REDACTED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Body(tt.contentType, []byte(tt.body)); got != tt.want {
+				t.Fatalf("Body mismatch:\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactor_Headers(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer tok")
+	h.Set("X-App-Token", "sbx:token")
+	h.Set("Content-Type", "application/json")
+
+	got := New(nil, nil).Headers(h)
+	if got.Get("Authorization") != Placeholder || got.Get("X-App-Token") != Placeholder {
+		t.Fatalf("expected credentials to be redacted: %v", got)
+	}
+	if got.Get("Content-Type") != "application/json" || !strings.HasPrefix(h.Get("Authorization"), "Bearer") {
+		t.Fatalf("expected other headers and the original to be untouched: %v / %v", got, h)
+	}
+}
+
+func TestRedactor_SensitiveObjectWithCustomFields(t *testing.T) {
+	r := New(nil, []string{"info"})
+	got := r.Body("application/json", []byte(`{"info":{"firstName":"Ann","nested":{"dob":"1990-01-01"}},"type":"individual"}`))
+	if want := `{"info":"REDACTED","type":"individual"}`; got != want {
+		t.Fatalf("Body mismatch:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestRedactor_URI(t *testing.T) {
+	r := New(nil, nil)
+	tests := map[string]string{
+		"/resources/applicants/-;externalUserId=ann%40example.com/one":                                   "/resources/applicants/-;externalUserId=REDACTED/one",
+		"/resources/sdkIntegrations/levels/-/websdkLink?externalUserId=u1&levelName=basic&ttlInSecs=600": "/resources/sdkIntegrations/levels/-/websdkLink?externalUserId=REDACTED&levelName=REDACTED&ttlInSecs=600",
+		"/v3.6/checks?applicant_id=ap-1":                                                                 "/v3.6/checks?applicant_id=REDACTED",
+		"/resources/applicants/a1/one":                                                                   "/resources/applicants/a1/one",
+		"/search?email=ann@example.com":                                                                  "/search?email=REDACTED",
+	}
+	for uri, want := range tests {
+		if got := r.URI(uri); got != want {
+			t.Fatalf("URI(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package signer
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -9,33 +10,62 @@ import (
 	"time"
 )
 
+// Credentials 在每次签名时返回 App Token 与 Secret Key，用于凭据轮换。
+type Credentials func(ctx context.Context) (appToken, secretKey string, err error)
+
 type HmacSigner struct {
-	appToken  string
-	secretKey string
-	now       func() time.Time
+	appToken    string
+	secretKey   string
+	credentials Credentials
+	now         func() time.Time
 }
 
 func New(appToken, secretKey string) *HmacSigner {
 	return &HmacSigner{appToken: appToken, secretKey: secretKey, now: time.Now}
 }
 
+// NewWithCredentials 创建每次签名时通过 creds 读取凭据的 signer。
+func NewWithCredentials(creds Credentials) *HmacSigner {
+	return &HmacSigner{credentials: creds, now: time.Now}
+}
+
 func (s *HmacSigner) Sign(method, path string, body any) (map[string]string, error) {
+	return s.SignContext(context.Background(), method, path, body)
+}
+
+// SignContext 与 Sign 相同，ctx 用于读取凭据。
+func (s *HmacSigner) SignContext(ctx context.Context, method, path string, body any) (map[string]string, error) {
+	appToken, secretKey := s.appToken, s.secretKey
+	if s.credentials != nil {
+		var err error
+		if appToken, secretKey, err = s.credentials(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	ts := strconv.FormatInt(s.now().Unix(), 10)
 
-	payload := ts + method + path
+	var bs []byte
 	if body != nil {
-		bs, err := json.Marshal(body)
+		var err error
+		bs, err = json.Marshal(body)
 		if err != nil {
 			return nil, err
 		}
-		payload += string(bs)
 	}
-	mac := hmac.New(sha256.New, []byte(s.secretKey))
-	mac.Write([]byte(payload))
 
 	return map[string]string{
-		"X-App-Token":      s.appToken,
+		"X-App-Token":      appToken,
 		"X-App-Access-Ts":  ts,
-		"X-App-Access-Sig": hex.EncodeToString(mac.Sum(nil)),
+		"X-App-Access-Sig": Signature(secretKey, ts, method, path, bs),
 	}, nil
 }
+
+// Signature 计算 X-App-Access-Sig：hex(HMAC-SHA256(secretKey, ts + method + path + body))。
+// 服务端（例如测试用的 fake server）可以用它按相同算法校验请求。
+func Signature(secretKey, ts, method, path string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(ts + method + path))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
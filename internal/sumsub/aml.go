@@ -0,0 +1,163 @@
+package sumsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dq/kyc-sdk/model"
+)
+
+type amlHitDTO struct {
+	Name          string   `json:"name"`
+	ListName      string   `json:"listName"`
+	Category      string   `json:"category"`
+	MatchStrength string   `json:"matchStrength"`
+	EntityType    string   `json:"entityType"`
+	Countries     []string `json:"countries"`
+}
+
+type amlCheckDTO struct {
+	ID        string `json:"id"`
+	Answer    string `json:"answer"`
+	CheckType string `json:"checkType"`
+	CreatedAt string `json:"createdAt"`
+	AML       struct {
+		Hits []amlHitDTO `json:"hits"`
+	} `json:"aml"`
+}
+
+type amlChecksDTO struct {
+	Checks []amlCheckDTO `json:"checks"`
+}
+
+func (p *Provider) RunAMLCheck(ctx context.Context, applicantID string) error {
+	if p == nil {
+		return errors.New("nil provider")
+	}
+	if strings.TrimSpace(applicantID) == "" {
+		return errors.New("missing applicant id")
+	}
+
+	path := "/resources/applicants/" + applicantID + "/recheck/aml"
+	headers, err := p.signer.SignContext(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return err
+	}
+	return p.http.PostJSON(ctx, path, nil, headers, nil)
+}
+
+func (p *Provider) GetAMLResults(ctx context.Context, applicantID string) (*model.AMLResult, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(applicantID) == "" {
+		return nil, errors.New("missing applicant id")
+	}
+
+	path := "/resources/checks/latest?type=AML&applicantId=" + url.QueryEscape(applicantID)
+	headers, err := p.signer.SignContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp amlChecksDTO
+	if err := p.http.GetJSON(ctx, path, headers, &resp); err != nil {
+		return nil, err
+	}
+
+	res := &model.AMLResult{
+		ApplicantID: applicantID,
+		Result:      model.ResultNone,
+	}
+	for _, check := range resp.Checks {
+		if check.CheckType != "" && check.CheckType != "AML" {
+			continue
+		}
+		res.CheckID = check.ID
+		res.Result = mapResult(check.Answer)
+		checkedAt, err := parseCheckTime(check.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res.CheckedAt = checkedAt
+		for _, h := range check.AML.Hits {
+			res.Hits = append(res.Hits, mapAMLHit(h))
+		}
+		break
+	}
+	return res, nil
+}
+
+// parseCheckTime 解析 check 的 createdAt：Sumsub 通常返回 "2006-01-02 15:04:05"（UTC），也兼容 RFC 3339。
+// 为空时返回零值。
+func parseCheckTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateTime, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse AML check createdAt %q: %w", s, err)
+	}
+	return t, nil
+}
+
+func mapAMLHit(dto amlHitDTO) model.AMLHit {
+	return model.AMLHit{
+		Name:          dto.Name,
+		ListName:      dto.ListName,
+		Category:      mapAMLCategory(dto.Category),
+		MatchStrength: mapAMLMatchStrength(dto.MatchStrength),
+		EntityType:    mapAMLEntityType(dto.EntityType),
+		Countries:     dto.Countries,
+	}
+}
+
+func mapAMLCategory(s string) model.AMLHitCategory {
+	switch strings.ToLower(s) {
+	case "sanction", "sanctions":
+		return model.AMLCategorySanctions
+	case "pep":
+		return model.AMLCategoryPEP
+	case "adverse_media", "adversemedia", "media":
+		return model.AMLCategoryAdverseMedia
+	default:
+		return model.AMLCategoryOther
+	}
+}
+
+func mapAMLMatchStrength(s string) model.AMLMatchStrength {
+	switch strings.ToLower(s) {
+	case "exact":
+		return model.AMLMatchExact
+	case "strong":
+		return model.AMLMatchStrong
+	case "medium":
+		return model.AMLMatchMedium
+	case "weak":
+		return model.AMLMatchWeak
+	default:
+		return model.AMLMatchUnknown
+	}
+}
+
+func mapAMLEntityType(s string) model.AMLEntityType {
+	switch strings.ToLower(s) {
+	case "person", "individual":
+		return model.AMLEntityPerson
+	case "organisation", "organization", "company":
+		return model.AMLEntityOrganization
+	case "vessel":
+		return model.AMLEntityVessel
+	default:
+		return model.AMLEntityUnknown
+	}
+}
@@ -0,0 +1,237 @@
+package sumsub
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dq/kyc-sdk/model"
+)
+
+type companyInfoDTO struct {
+	CompanyName        string           `json:"companyName,omitempty"`
+	RegistrationNumber string           `json:"registrationNumber,omitempty"`
+	Country            string           `json:"country,omitempty"`
+	LegalAddress       string           `json:"legalAddress,omitempty"`
+	Email              string           `json:"email,omitempty"`
+	Phone              string           `json:"phone,omitempty"`
+	Beneficiaries      []beneficiaryDTO `json:"beneficiaries,omitempty"`
+}
+
+type beneficiaryDTO struct {
+	ID          string   `json:"id,omitempty"`
+	ApplicantID string   `json:"applicantId"`
+	Types       []string `json:"types,omitempty"`
+	ShareSize   float64  `json:"shareSize,omitempty"`
+}
+
+type createCompanyRequest struct {
+	ExternalUserID string `json:"externalUserId"`
+	Type           string `json:"type"`
+	Info           struct {
+		CompanyInfo companyInfoDTO `json:"companyInfo"`
+	} `json:"info"`
+}
+
+type companyApplicantDTO struct {
+	applicantDTO
+	Info struct {
+		CompanyInfo companyInfoDTO `json:"companyInfo"`
+	} `json:"info"`
+}
+
+func (p *Provider) CreateCompanyApplicant(ctx context.Context, req model.CreateCompanyRequest) (*model.CompanyInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(req.UserID) == "" {
+		return nil, errors.New("missing user id")
+	}
+	if strings.TrimSpace(req.LevelName) == "" {
+		return nil, errors.New("missing level name")
+	}
+	if strings.TrimSpace(req.CompanyName) == "" {
+		return nil, errors.New("missing company name")
+	}
+
+	path := "/resources/applicants?levelName=" + url.QueryEscape(req.LevelName)
+	body := createCompanyRequest{
+		ExternalUserID: req.UserID,
+		Type:           "company",
+	}
+	body.Info.CompanyInfo = companyInfoDTO{
+		CompanyName:        strings.TrimSpace(req.CompanyName),
+		RegistrationNumber: strings.TrimSpace(req.RegistrationNumber),
+		Country:            strings.TrimSpace(req.Country),
+		LegalAddress:       strings.TrimSpace(req.LegalAddress),
+		Email:              strings.TrimSpace(req.Email),
+		Phone:              strings.TrimSpace(req.Phone),
+	}
+
+	headers, err := p.signer.SignContext(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp companyApplicantDTO
+	if err := p.http.PostJSON(ctx, path, body, headers, &resp); err != nil {
+		return nil, err
+	}
+
+	return mapCompany(resp), nil
+}
+
+// GetCompany 查询企业 applicant，并逐个查询受益人的个人 applicant 以汇总 KYB 结果。
+func (p *Provider) GetCompany(ctx context.Context, applicantID string) (*model.CompanyInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+
+	resp, err := p.getCompanyApplicant(ctx, applicantID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := mapCompany(*resp)
+	for i, b := range info.Beneficiaries {
+		// 尚未关联个人 applicant 的受益人无法查询，标记为 UNKNOWN，企业也就不会被汇总为 GREEN。
+		if strings.TrimSpace(b.ApplicantID) == "" {
+			info.Beneficiaries[i].Status = model.StatusUnknown
+			info.Beneficiaries[i].Result = model.ResultNone
+			continue
+		}
+		applicant, err := p.GetApplicant(ctx, b.ApplicantID)
+		if err != nil {
+			return nil, err
+		}
+		info.Beneficiaries[i].Status = applicant.Status
+		info.Beneficiaries[i].Result = applicant.Result
+	}
+	info.Status, info.Result = aggregateKYB(info)
+
+	return info, nil
+}
+
+func (p *Provider) AddBeneficiary(ctx context.Context, companyApplicantID string, req model.AddBeneficiaryRequest) (*model.Beneficiary, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(companyApplicantID) == "" {
+		return nil, errors.New("missing company applicant id")
+	}
+	if strings.TrimSpace(req.ApplicantID) == "" {
+		return nil, errors.New("missing beneficiary applicant id")
+	}
+	if len(req.Types) == 0 {
+		return nil, errors.New("missing beneficiary types")
+	}
+
+	path := "/resources/applicants/" + companyApplicantID + "/info/companyInfo/beneficiaries"
+	body := beneficiaryDTO{
+		ApplicantID: req.ApplicantID,
+		ShareSize:   req.ShareSize,
+	}
+	for _, t := range req.Types {
+		body.Types = append(body.Types, string(t))
+	}
+
+	headers, err := p.signer.SignContext(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp beneficiaryDTO
+	if err := p.http.PostJSON(ctx, path, body, headers, &resp); err != nil {
+		return nil, err
+	}
+
+	b := mapBeneficiary(resp)
+	return &b, nil
+}
+
+func (p *Provider) ListBeneficiaries(ctx context.Context, companyApplicantID string) ([]model.Beneficiary, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+
+	resp, err := p.getCompanyApplicant(ctx, companyApplicantID)
+	if err != nil {
+		return nil, err
+	}
+	return mapCompany(*resp).Beneficiaries, nil
+}
+
+func (p *Provider) getCompanyApplicant(ctx context.Context, applicantID string) (*companyApplicantDTO, error) {
+	if strings.TrimSpace(applicantID) == "" {
+		return nil, errors.New("missing applicant id")
+	}
+
+	path := "/resources/applicants/" + applicantID
+	headers, err := p.signer.SignContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp companyApplicantDTO
+	if err := p.http.GetJSON(ctx, path, headers, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func mapCompany(dto companyApplicantDTO) *model.CompanyInfo {
+	ci := dto.Info.CompanyInfo
+	info := &model.CompanyInfo{
+		UserID:             dto.ExternalUserID,
+		ApplicantID:        dto.ID,
+		CompanyName:        ci.CompanyName,
+		RegistrationNumber: ci.RegistrationNumber,
+		Country:            ci.Country,
+		LegalAddress:       ci.LegalAddress,
+		Status:             mapStatus(dto.Review.ReviewStatus),
+		Result:             mapResult(dto.Review.ReviewResult.ReviewAnswer),
+		Provider:           "sumsub",
+	}
+	for _, b := range ci.Beneficiaries {
+		info.Beneficiaries = append(info.Beneficiaries, mapBeneficiary(b))
+	}
+	return info
+}
+
+func mapBeneficiary(dto beneficiaryDTO) model.Beneficiary {
+	b := model.Beneficiary{
+		ID:          dto.ID,
+		ApplicantID: dto.ApplicantID,
+		ShareSize:   dto.ShareSize,
+		Status:      model.StatusUnknown,
+		Result:      model.ResultNone,
+	}
+	for _, t := range dto.Types {
+		b.Types = append(b.Types, model.BeneficiaryType(t))
+	}
+	return b
+}
+
+func aggregateKYB(info *model.CompanyInfo) (model.KycStatus, model.KycResult) {
+	status, result := info.Status, info.Result
+	for _, b := range info.Beneficiaries {
+		switch {
+		case status == model.StatusPending || b.Status == model.StatusPending:
+			status = model.StatusPending
+		case status == model.StatusUnknown || b.Status == model.StatusUnknown:
+			status = model.StatusUnknown
+		}
+
+		switch {
+		case result == model.ResultRed || b.Result == model.ResultRed:
+			result = model.ResultRed
+		case result == model.ResultYellow || b.Result == model.ResultYellow:
+			result = model.ResultYellow
+		case result == model.ResultNone || b.Result == model.ResultNone:
+			result = model.ResultNone
+		}
+	}
+	return status, result
+}
@@ -28,21 +28,40 @@ func New(cfg *config.Config) (*Provider, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("%w: nil", kycerrors.ErrInvalidConfig)
 	}
-	if strings.TrimSpace(cfg.BaseURL) == "" {
-		return nil, fmt.Errorf("%w: BaseURL required", kycerrors.ErrInvalidConfig)
-	}
-	if strings.TrimSpace(cfg.AppToken) == "" {
-		return nil, fmt.Errorf("%w: AppToken required", kycerrors.ErrInvalidConfig)
-	}
-	if strings.TrimSpace(cfg.SecretKey) == "" {
-		return nil, fmt.Errorf("%w: SecretKey required", kycerrors.ErrInvalidConfig)
+	if err := cfg.ValidateFor("sumsub"); err != nil {
+		return nil, err
 	}
-	if strings.TrimSpace(cfg.WebhookSecret) == "" {
+	if cfg.Secrets == nil && strings.TrimSpace(cfg.WebhookSecret) == "" {
 		return nil, fmt.Errorf("%w: Webhook SecretKey required", kycerrors.ErrInvalidConfig)
 	}
 
-	http := httpclient.New(cfg.BaseURL, cfg.TimeoutSec)
-	sig := signer.New(cfg.AppToken, cfg.SecretKey)
+	if cfg.Secrets != nil {
+		if _, ok := cfg.Secrets.(*config.SecretCache); !ok {
+			c := *cfg
+			c.Secrets = config.CacheSecrets(cfg.Secrets, config.DefaultSecretTTL)
+			cfg = &c
+		}
+	}
+
+	http := httpclient.New(cfg.BaseURL, cfg.TimeoutSec, httpclient.WithTransport(cfg.Transport), httpclient.WithRateLimiter(cfg.RateLimiter), httpclient.WithCircuitBreaker(cfg.CircuitBreaker))
+	sig := signer.NewWithCredentials(func(ctx context.Context) (string, string, error) {
+		appToken, err := cfg.ResolveSecret(ctx, config.SecretAppToken, cfg.AppToken)
+		if err != nil {
+			return "", "", err
+		}
+		secretKey, err := cfg.ResolveSecret(ctx, config.SecretSecretKey, cfg.SecretKey)
+		if err != nil {
+			return "", "", err
+		}
+		if appToken == "" || secretKey == "" {
+			return "", "", fmt.Errorf("%w: AppToken and SecretKey required", kycerrors.ErrInvalidConfig)
+		}
+		// 通过 Secrets 读取的 token 无法在创建时校验，在使用时检查前缀与 Environment 是否一致。
+		if err := cfg.Environment.CheckToken(appToken); err != nil {
+			return "", "", err
+		}
+		return appToken, secretKey, nil
+	})
 
 	return &Provider{
 		cfg:    cfg,
@@ -72,7 +91,7 @@ func (p *Provider) CreateApplicant(ctx context.Context, userID string) (*model.A
 		"externalUserId": userID,
 	}
 
-	headers, err := p.signer.Sign(http.MethodPost, path, body)
+	headers, err := p.signer.SignContext(ctx, http.MethodPost, path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +110,7 @@ func (p *Provider) GetApplicant(ctx context.Context, applicantID string) (*model
 	}
 
 	path := "/resources/applicants/" + applicantID
-	headers, err := p.signer.Sign(http.MethodGet, path, nil)
+	headers, err := p.signer.SignContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -161,7 +180,7 @@ func (p *Provider) GenerateLink(ctx context.Context, req model.GenerateLinkReque
 		}
 	}
 
-	headers, err := p.signer.Sign(http.MethodPost, path, body)
+	headers, err := p.signer.SignContext(ctx, http.MethodPost, path, body)
 	if err != nil {
 		return "", err
 	}
@@ -192,23 +211,41 @@ type webhookPayload struct {
 	// - RED：拒绝
 	// - YELLOW：需要进一步处理/人工复核（具体策略由业务决定）
 	ReviewResult struct {
-		ReviewAnswer string `json:"reviewAnswer"`
+		ReviewAnswer string   `json:"reviewAnswer"`
+		RejectLabels []string `json:"rejectLabels"`
 	} `json:"reviewResult"`
+	// KytDataTxnID 是交易监控事件中业务侧提交的交易 ID。
+	KytDataTxnID string `json:"kytDataTxnId"`
+	// SandboxMode 表示回调是否来自 sandbox 环境（旧版回调可能不携带）。
+	SandboxMode *bool `json:"sandboxMode"`
 }
 
 func (p *Provider) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	return p.VerifyAndParseWebhookContext(context.Background(), headers, rawBody)
+}
+
+// VerifyAndParseWebhookContext 与 VerifyAndParseWebhook 相同，ctx 用于从 Config.Secrets 读取 WebhookSecret。
+func (p *Provider) VerifyAndParseWebhookContext(ctx context.Context, headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
 	if p == nil {
 		return nil, errors.New("nil provider")
 	}
 
-	sig := strings.TrimSpace(headers.Get("X-Payload-Digest"))
+	secret, err := p.cfg.ResolveSecret(ctx, config.SecretWebhookSecret, p.cfg.WebhookSecret)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(secret) == "" {
+		return nil, fmt.Errorf("%w: WebhookSecret required", kycerrors.ErrInvalidConfig)
+	}
+
+	sig := strings.TrimSpace(headers.Get(signatureHeader))
 	if sig == "" {
-		return nil, errors.New("missing signature")
+		return nil, kycerrors.ErrMissingSignature
 	}
 
-	verified := verifyWebhookDigest(sig, p.cfg.WebhookSecret, rawBody)
+	verified := verifyWebhookDigest(sig, secret, rawBody)
 	if !verified {
-		return nil, errors.New("invalid signature")
+		return nil, kycerrors.ErrInvalidSignature
 	}
 
 	in := webhookPayload{}
@@ -216,19 +253,51 @@ func (p *Provider) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*
 		return nil, fmt.Errorf("parse webhook payload: %w", err)
 	}
 
+	// sandbox 与 production 可能配置了相同的回调地址，另一个环境的回调不应被处理。
+	env := p.cfg.Env()
+	if in.SandboxMode != nil {
+		got := config.EnvProduction
+		if *in.SandboxMode {
+			got = config.EnvSandbox
+		}
+		if env != "" && got != env {
+			return nil, fmt.Errorf("%w: webhook from %s, client configured for %s", kycerrors.ErrEnvironmentMismatch, got, env)
+		}
+		env = got
+	}
+
 	return &model.WebhookPayload{
 		Type:           in.Type,
 		ApplicantID:    in.ApplicantID,
 		ExternalUserID: in.ExternalUserID,
 		ReviewStatus:   in.ReviewStatus,
+		ReviewResult:   mapResult(in.ReviewResult.ReviewAnswer),
+		RejectLabels:   in.ReviewResult.RejectLabels,
+		TransactionID:  in.KytDataTxnID,
+		Environment:    string(env),
 	}, nil
 }
 
+// signatureHeader 是 Sumsub Webhook 携带签名的 header。
+const signatureHeader = "X-Payload-Digest"
+
+// WebhookSignatureHeader 返回 Webhook 签名 header，供 Router 识别回调来源。
+func (p *Provider) WebhookSignatureHeader() string {
+	return signatureHeader
+}
+
+// Environment 返回配置的环境（见 config.Config.Env）。
+func (p *Provider) Environment() config.Environment {
+	return p.cfg.Env()
+}
+
+// verifyWebhookDigest 校验十六进制 HMAC-SHA256 摘要，允许带 "sha256=" 前缀。
 func verifyWebhookDigest(signature, secretKey string, rawBody []byte) bool {
 	mac := hmac.New(sha256.New, []byte(secretKey))
 	mac.Write(rawBody)
 	expectedSignature := hex.EncodeToString(mac.Sum(nil))
-	return expectedSignature == signature
+	signature = strings.TrimPrefix(signature, "sha256=")
+	return hmac.Equal([]byte(expectedSignature), []byte(signature))
 }
 
 func mapApplicant(dto applicantDTO) *model.ApplicantInfo {
@@ -245,7 +314,7 @@ func mapStatus(s string) model.KycStatus {
 	switch s {
 	case "completed", "reviewed":
 		return model.StatusReviewed
-	case "pending":
+	case "pending", "onHold":
 		return model.StatusPending
 	default:
 		return model.StatusUnknown
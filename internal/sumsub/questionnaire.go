@@ -0,0 +1,127 @@
+package sumsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+type questionnaireItemDTO struct {
+	Value  string   `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+type questionnaireSectionDTO struct {
+	Items map[string]questionnaireItemDTO `json:"items"`
+}
+
+type questionnaireDTO struct {
+	ID       string                             `json:"id"`
+	Sections map[string]questionnaireSectionDTO `json:"sections"`
+}
+
+type questionnairesDTO struct {
+	Questionnaires []questionnaireDTO `json:"questionnaires"`
+}
+
+func (p *Provider) GetQuestionnaire(ctx context.Context, applicantID, questionnaireID string) (*model.Questionnaire, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(applicantID) == "" {
+		return nil, errors.New("missing applicant id")
+	}
+	if strings.TrimSpace(questionnaireID) == "" {
+		return nil, errors.New("missing questionnaire id")
+	}
+
+	path := "/resources/applicants/" + applicantID
+	headers, err := p.signer.SignContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp questionnairesDTO
+	if err := p.http.GetJSON(ctx, path, headers, &resp); err != nil {
+		return nil, err
+	}
+
+	for _, q := range resp.Questionnaires {
+		if q.ID == questionnaireID {
+			return mapQuestionnaire(q), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: questionnaire %s", kycerrors.ErrNotFound, questionnaireID)
+}
+
+func (p *Provider) SubmitQuestionnaire(ctx context.Context, applicantID string, q model.Questionnaire) error {
+	if p == nil {
+		return errors.New("nil provider")
+	}
+	if strings.TrimSpace(applicantID) == "" {
+		return errors.New("missing applicant id")
+	}
+	if strings.TrimSpace(q.ID) == "" {
+		return errors.New("missing questionnaire id")
+	}
+
+	path := "/resources/applicants/" + applicantID + "/questionnaires"
+	body := toQuestionnaireDTO(q)
+
+	headers, err := p.signer.SignContext(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return err
+	}
+	return p.http.PostJSON(ctx, path, body, headers, nil)
+}
+
+func mapQuestionnaire(dto questionnaireDTO) *model.Questionnaire {
+	q := &model.Questionnaire{ID: dto.ID}
+
+	sections := make([]string, 0, len(dto.Sections))
+	for id := range dto.Sections {
+		sections = append(sections, id)
+	}
+	sort.Strings(sections)
+
+	for _, section := range sections {
+		items := dto.Sections[section].Items
+		ids := make([]string, 0, len(items))
+		for id := range items {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, item := range ids {
+			q.Answers = append(q.Answers, model.QuestionnaireAnswer{
+				Section: section,
+				Item:    item,
+				Value:   items[item].Value,
+				Values:  items[item].Values,
+			})
+		}
+	}
+	return q
+}
+
+func toQuestionnaireDTO(q model.Questionnaire) questionnaireDTO {
+	dto := questionnaireDTO{
+		ID:       q.ID,
+		Sections: make(map[string]questionnaireSectionDTO),
+	}
+	for _, a := range q.Answers {
+		section, ok := dto.Sections[a.Section]
+		if !ok {
+			section = questionnaireSectionDTO{Items: make(map[string]questionnaireItemDTO)}
+			dto.Sections[a.Section] = section
+		}
+		section.Items[a.Item] = questionnaireItemDTO{Value: a.Value, Values: a.Values}
+	}
+	return dto
+}
@@ -0,0 +1,50 @@
+package sumsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+type testCompletedRequest struct {
+	ReviewAnswer     string   `json:"reviewAnswer"`
+	RejectLabels     []string `json:"rejectLabels,omitempty"`
+	ReviewRejectType string   `json:"reviewRejectType,omitempty"`
+}
+
+// SimulateReview 调用 sandbox 的 testCompleted 接口强制审核结论。RED 按最终拒绝（FINAL）处理。
+func (p *Provider) SimulateReview(ctx context.Context, applicantID string, answer model.KycResult, rejectLabels []string) error {
+	if p == nil {
+		return errors.New("nil provider")
+	}
+	if strings.TrimSpace(applicantID) == "" {
+		return errors.New("missing applicant id")
+	}
+	// production 上该接口不可用，提前拒绝，避免凭据配置错误时误操作真实 applicant。
+	if env := p.cfg.Env(); env != config.EnvSandbox {
+		return fmt.Errorf("%w: SimulateReview (environment: %q)", kycerrors.ErrSandboxOnly, env)
+	}
+
+	body := testCompletedRequest{ReviewAnswer: string(answer)}
+	switch answer {
+	case model.ResultGreen:
+	case model.ResultRed:
+		body.RejectLabels = rejectLabels
+		body.ReviewRejectType = "FINAL"
+	default:
+		return fmt.Errorf("%w: unsupported review answer %q", kycerrors.ErrBadRequest, answer)
+	}
+
+	path := "/resources/applicants/" + applicantID + "/status/testCompleted"
+	headers, err := p.signer.SignContext(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return err
+	}
+	return p.http.PostJSON(ctx, path, body, headers, nil)
+}
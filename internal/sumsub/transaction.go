@@ -0,0 +1,186 @@
+package sumsub
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dq/kyc-sdk/model"
+)
+
+const txnTimeLayout = "2006-01-02 15:04:05-0700"
+
+type paymentMethodDTO struct {
+	Type      string `json:"type,omitempty"`
+	AccountID string `json:"accountId,omitempty"`
+	Issuer    string `json:"issuer,omitempty"`
+}
+
+type counterpartyDTO struct {
+	ExternalUserID string            `json:"externalUserId,omitempty"`
+	FullName       string            `json:"fullName,omitempty"`
+	Type           string            `json:"type,omitempty"`
+	Country        string            `json:"residenceCountry,omitempty"`
+	PaymentMethod  *paymentMethodDTO `json:"paymentMethod,omitempty"`
+}
+
+type ipInfoDTO struct {
+	IP string `json:"ip"`
+}
+
+type deviceDTO struct {
+	IPInfo      *ipInfoDTO `json:"ipInfo,omitempty"`
+	Fingerprint string     `json:"fingerprint,omitempty"`
+	UserAgent   string     `json:"userAgent,omitempty"`
+}
+
+type txnApplicantDTO struct {
+	PaymentMethod *paymentMethodDTO `json:"paymentMethod,omitempty"`
+	Device        *deviceDTO        `json:"device,omitempty"`
+}
+
+type txnInfoDTO struct {
+	Direction    string  `json:"direction"`
+	Amount       float64 `json:"amount"`
+	CurrencyCode string  `json:"currencyCode"`
+}
+
+type txnDataDTO struct {
+	TxnID        string           `json:"txnId"`
+	TxnDate      string           `json:"txnDate"`
+	Type         string           `json:"type"`
+	Info         txnInfoDTO       `json:"info"`
+	Applicant    *txnApplicantDTO `json:"applicant,omitempty"`
+	Counterparty *counterpartyDTO `json:"counterparty,omitempty"`
+}
+
+type txnDTO struct {
+	ID          string     `json:"id"`
+	ApplicantID string     `json:"applicantId"`
+	Data        txnDataDTO `json:"data"`
+	Score       float64    `json:"score"`
+	Review      struct {
+		ReviewStatus string `json:"reviewStatus"`
+		ReviewResult struct {
+			ReviewAnswer string `json:"reviewAnswer"`
+		} `json:"reviewResult"`
+	} `json:"review"`
+}
+
+func (p *Provider) SubmitTransaction(ctx context.Context, applicantID string, tx model.Transaction) (*model.TransactionReview, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(applicantID) == "" {
+		return nil, errors.New("missing applicant id")
+	}
+	if strings.TrimSpace(tx.ID) == "" {
+		return nil, errors.New("missing transaction id")
+	}
+	if strings.TrimSpace(tx.Currency) == "" {
+		return nil, errors.New("missing currency")
+	}
+
+	path := "/resources/applicants/" + applicantID + "/kyt/txns/-/data"
+	body := mapTransaction(tx, time.Now())
+
+	headers, err := p.signer.SignContext(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp txnDTO
+	if err := p.http.PostJSON(ctx, path, body, headers, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ApplicantID == "" {
+		resp.ApplicantID = applicantID
+	}
+	return mapTransactionReview(resp), nil
+}
+
+func (p *Provider) GetTransactionReview(ctx context.Context, transactionID string) (*model.TransactionReview, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(transactionID) == "" {
+		return nil, errors.New("missing transaction id")
+	}
+
+	path := "/resources/kyt/txns/" + transactionID + "/one"
+	headers, err := p.signer.SignContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp txnDTO
+	if err := p.http.GetJSON(ctx, path, headers, &resp); err != nil {
+		return nil, err
+	}
+	return mapTransactionReview(resp), nil
+}
+
+func mapTransaction(tx model.Transaction, now time.Time) txnDataDTO {
+	at := tx.OccurredAt
+	if at.IsZero() {
+		at = now
+	}
+
+	direction := tx.Direction
+	if direction == "" {
+		direction = model.TransactionIn
+	}
+
+	data := txnDataDTO{
+		TxnID:   tx.ID,
+		TxnDate: at.UTC().Format(txnTimeLayout),
+		Type:    "finance",
+		Info: txnInfoDTO{
+			Direction:    string(direction),
+			Amount:       tx.Amount,
+			CurrencyCode: tx.Currency,
+		},
+	}
+
+	applicant := &txnApplicantDTO{PaymentMethod: mapPaymentMethod(tx.PaymentMethod)}
+	if d := tx.Device; d != (model.DeviceInfo{}) {
+		applicant.Device = &deviceDTO{Fingerprint: d.Fingerprint, UserAgent: d.UserAgent}
+		if d.IPAddress != "" {
+			applicant.Device.IPInfo = &ipInfoDTO{IP: d.IPAddress}
+		}
+	}
+	if applicant.PaymentMethod != nil || applicant.Device != nil {
+		data.Applicant = applicant
+	}
+
+	if cp := tx.Counterparty; cp != (model.Counterparty{}) {
+		data.Counterparty = &counterpartyDTO{
+			ExternalUserID: cp.ExternalUserID,
+			FullName:       cp.FullName,
+			Type:           cp.Type,
+			Country:        cp.Country,
+			PaymentMethod:  mapPaymentMethod(cp.PaymentMethod),
+		}
+	}
+	return data
+}
+
+func mapPaymentMethod(pm model.PaymentMethod) *paymentMethodDTO {
+	if pm == (model.PaymentMethod{}) {
+		return nil
+	}
+	return &paymentMethodDTO{Type: pm.Type, AccountID: pm.AccountID, Issuer: pm.Issuer}
+}
+
+func mapTransactionReview(dto txnDTO) *model.TransactionReview {
+	return &model.TransactionReview{
+		ID:            dto.ID,
+		TransactionID: dto.Data.TxnID,
+		ApplicantID:   dto.ApplicantID,
+		Status:        mapStatus(dto.Review.ReviewStatus),
+		Result:        mapResult(dto.Review.ReviewResult.ReviewAnswer),
+		Score:         dto.Score,
+	}
+}
@@ -0,0 +1,232 @@
+package sumsub
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dq/kyc-sdk/model"
+)
+
+type institutionDTO struct {
+	InternalID string `json:"internalId,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+type travelRulePartyDTO struct {
+	ExternalUserID  string            `json:"externalUserId,omitempty"`
+	FullName        string            `json:"fullName,omitempty"`
+	Type            string            `json:"type,omitempty"`
+	Country         string            `json:"residenceCountry,omitempty"`
+	PaymentMethod   *paymentMethodDTO `json:"paymentMethod,omitempty"`
+	InstitutionInfo *institutionDTO   `json:"institutionInfo,omitempty"`
+}
+
+type cryptoParamsDTO struct {
+	CryptoChain string `json:"cryptoChain,omitempty"`
+	TxHash      string `json:"txnHash,omitempty"`
+}
+
+type travelRuleInfoDTO struct {
+	Direction    string           `json:"direction"`
+	Amount       float64          `json:"amount"`
+	CurrencyCode string           `json:"currencyCode"`
+	CurrencyType string           `json:"currencyType"`
+	CryptoParams *cryptoParamsDTO `json:"cryptoParams,omitempty"`
+}
+
+type travelRuleDataDTO struct {
+	TxnID        string              `json:"txnId"`
+	TxnDate      string              `json:"txnDate"`
+	Type         string              `json:"type"`
+	Info         travelRuleInfoDTO   `json:"info"`
+	Applicant    *travelRulePartyDTO `json:"applicant,omitempty"`
+	Counterparty *travelRulePartyDTO `json:"counterparty,omitempty"`
+}
+
+type travelRuleDTO struct {
+	ID          string            `json:"id"`
+	ApplicantID string            `json:"applicantId"`
+	Data        travelRuleDataDTO `json:"data"`
+	TravelRule  struct {
+		Status string `json:"status"`
+	} `json:"travelRuleInfo"`
+	Review struct {
+		ReviewStatus string `json:"reviewStatus"`
+		ReviewResult struct {
+			ReviewAnswer string `json:"reviewAnswer"`
+		} `json:"reviewResult"`
+	} `json:"review"`
+}
+
+type travelRuleRejectRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+func (p *Provider) SubmitTravelRuleTransfer(ctx context.Context, applicantID string, transfer model.TravelRuleTransfer) (*model.TravelRuleInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(applicantID) == "" {
+		return nil, errors.New("missing applicant id")
+	}
+	if strings.TrimSpace(transfer.ID) == "" {
+		return nil, errors.New("missing transfer id")
+	}
+	if strings.TrimSpace(transfer.Asset) == "" {
+		return nil, errors.New("missing asset")
+	}
+
+	path := "/resources/applicants/" + applicantID + "/kyt/txns/-/data"
+	body := mapTravelRuleTransfer(transfer, time.Now())
+
+	headers, err := p.signer.SignContext(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp travelRuleDTO
+	if err := p.http.PostJSON(ctx, path, body, headers, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ApplicantID == "" {
+		resp.ApplicantID = applicantID
+	}
+	return mapTravelRuleInfo(resp), nil
+}
+
+func (p *Provider) GetTravelRuleTransfer(ctx context.Context, transferID string) (*model.TravelRuleInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(transferID) == "" {
+		return nil, errors.New("missing transfer id")
+	}
+
+	path := "/resources/kyt/txns/" + transferID + "/one"
+	headers, err := p.signer.SignContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp travelRuleDTO
+	if err := p.http.GetJSON(ctx, path, headers, &resp); err != nil {
+		return nil, err
+	}
+	return mapTravelRuleInfo(resp), nil
+}
+
+func (p *Provider) ConfirmTravelRuleTransfer(ctx context.Context, transferID string) (*model.TravelRuleInfo, error) {
+	return p.reviewTravelRuleTransfer(ctx, transferID, "confirm", nil)
+}
+
+func (p *Provider) RejectTravelRuleTransfer(ctx context.Context, transferID, reason string) (*model.TravelRuleInfo, error) {
+	return p.reviewTravelRuleTransfer(ctx, transferID, "reject", &travelRuleRejectRequest{Reason: strings.TrimSpace(reason)})
+}
+
+func (p *Provider) reviewTravelRuleTransfer(ctx context.Context, transferID, action string, body any) (*model.TravelRuleInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(transferID) == "" {
+		return nil, errors.New("missing transfer id")
+	}
+
+	path := "/resources/kyt/txns/" + transferID + "/travelRule/" + action
+	headers, err := p.signer.SignContext(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp travelRuleDTO
+	if err := p.http.PostJSON(ctx, path, body, headers, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ID == "" {
+		resp.ID = transferID
+	}
+	return mapTravelRuleInfo(resp), nil
+}
+
+func mapTravelRuleTransfer(t model.TravelRuleTransfer, now time.Time) travelRuleDataDTO {
+	at := t.OccurredAt
+	if at.IsZero() {
+		at = now
+	}
+
+	direction := t.Direction
+	if direction == "" {
+		direction = model.TransactionOut
+	}
+
+	data := travelRuleDataDTO{
+		TxnID:   t.ID,
+		TxnDate: at.UTC().Format(txnTimeLayout),
+		Type:    "travelRule",
+		Info: travelRuleInfoDTO{
+			Direction:    string(direction),
+			Amount:       t.Amount,
+			CurrencyCode: t.Asset,
+			CurrencyType: "crypto",
+		},
+	}
+	if t.Network != "" || t.TxHash != "" {
+		data.Info.CryptoParams = &cryptoParamsDTO{CryptoChain: t.Network, TxHash: t.TxHash}
+	}
+
+	// applicant 始终是本方用户：提币时是发起方，充币时是受益方。
+	self, other := t.Originator, t.Beneficiary
+	if direction == model.TransactionIn {
+		self, other = t.Beneficiary, t.Originator
+	}
+	data.Applicant = mapTravelRuleParty(self)
+	data.Counterparty = mapTravelRuleParty(other)
+	return data
+}
+
+func mapTravelRuleParty(party model.TravelRuleParty) *travelRulePartyDTO {
+	if party == (model.TravelRuleParty{}) {
+		return nil
+	}
+
+	dto := &travelRulePartyDTO{
+		ExternalUserID: party.ExternalUserID,
+		FullName:       party.FullName,
+		Type:           party.Type,
+		Country:        party.Country,
+	}
+	if party.WalletAddress != "" {
+		dto.PaymentMethod = &paymentMethodDTO{Type: "crypto", AccountID: party.WalletAddress}
+	}
+	if party.VASP != (model.VASP{}) {
+		dto.InstitutionInfo = &institutionDTO{InternalID: party.VASP.ID, Name: party.VASP.Name}
+	}
+	return dto
+}
+
+func mapTravelRuleInfo(dto travelRuleDTO) *model.TravelRuleInfo {
+	return &model.TravelRuleInfo{
+		ID:          dto.ID,
+		TransferID:  dto.Data.TxnID,
+		ApplicantID: dto.ApplicantID,
+		Status:      mapTravelRuleStatus(dto.TravelRule.Status),
+		Result:      mapResult(dto.Review.ReviewResult.ReviewAnswer),
+	}
+}
+
+func mapTravelRuleStatus(s string) model.TravelRuleStatus {
+	switch s {
+	case "pending", "init":
+		return model.TravelRulePending
+	case "awaitingCounterparty", "awaiting":
+		return model.TravelRuleAwaitingCounterparty
+	case "confirmed", "completed":
+		return model.TravelRuleConfirmed
+	case "rejected":
+		return model.TravelRuleRejected
+	default:
+		return model.TravelRuleUnknown
+	}
+}
@@ -0,0 +1,282 @@
+package veriff
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/internal/httpclient"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+const defaultBaseURL = "https://stationapi.veriff.com"
+
+type Provider struct {
+	cfg  config.VeriffConfig
+	http *httpclient.Client
+}
+
+func New(cfg *config.Config) (*Provider, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: nil", kycerrors.ErrInvalidConfig)
+	}
+
+	vc := cfg.Veriff
+	if strings.TrimSpace(vc.APIKey) == "" {
+		return nil, fmt.Errorf("%w: Veriff.APIKey required", kycerrors.ErrInvalidConfig)
+	}
+	if strings.TrimSpace(vc.SharedSecret) == "" {
+		return nil, fmt.Errorf("%w: Veriff.SharedSecret required", kycerrors.ErrInvalidConfig)
+	}
+	if strings.TrimSpace(vc.BaseURL) == "" {
+		vc.BaseURL = defaultBaseURL
+	}
+
+	return &Provider{
+		cfg:  vc,
+		http: httpclient.New(vc.BaseURL, cfg.TimeoutSec, httpclient.WithTransport(cfg.Transport), httpclient.WithRateLimiter(cfg.RateLimiter), httpclient.WithCircuitBreaker(cfg.CircuitBreaker)),
+	}, nil
+}
+
+// sign 计算 Veriff 的 X-HMAC-SIGNATURE：对 payload 做 HMAC-SHA256 后取 hex。
+func (p *Provider) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.cfg.SharedSecret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type sessionRequest struct {
+	Verification struct {
+		Callback   string `json:"callback,omitempty"`
+		VendorData string `json:"vendorData"`
+	} `json:"verification"`
+}
+
+type sessionDTO struct {
+	Status       string `json:"status"`
+	Verification struct {
+		ID         string `json:"id"`
+		URL        string `json:"url"`
+		VendorData string `json:"vendorData"`
+		Status     string `json:"status"`
+	} `json:"verification"`
+}
+
+type decisionDTO struct {
+	Status       string `json:"status"`
+	Verification *struct {
+		ID         string `json:"id"`
+		Status     string `json:"status"`
+		VendorData string `json:"vendorData"`
+	} `json:"verification"`
+}
+
+// CreateApplicant 在 Veriff 中创建一个 session，session ID 即 ApplicantID。
+func (p *Provider) CreateApplicant(ctx context.Context, userID string) (*model.ApplicantInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+
+	session, err := p.createSession(ctx, userID, p.cfg.CallbackURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ApplicantInfo{
+		UserID:      session.Verification.VendorData,
+		ApplicantID: session.Verification.ID,
+		Status:      model.StatusPending,
+		Result:      model.ResultNone,
+		Provider:    "veriff",
+	}, nil
+}
+
+// GetApplicant 查询 session 的 decision；尚未出结论时返回 PENDING。
+func (p *Provider) GetApplicant(ctx context.Context, applicantID string) (*model.ApplicantInfo, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+	if strings.TrimSpace(applicantID) == "" {
+		return nil, errors.New("missing applicant id")
+	}
+
+	path := "/v1/sessions/" + applicantID + "/decision"
+	headers := map[string]string{
+		"X-AUTH-CLIENT":    p.cfg.APIKey,
+		"X-HMAC-SIGNATURE": p.sign([]byte(applicantID)),
+	}
+
+	var resp decisionDTO
+	if err := p.http.GetJSON(ctx, path, headers, &resp); err != nil {
+		return nil, err
+	}
+
+	info := &model.ApplicantInfo{
+		ApplicantID: applicantID,
+		Status:      model.StatusPending,
+		Result:      model.ResultNone,
+		Provider:    "veriff",
+	}
+	if v := resp.Verification; v != nil {
+		info.UserID = v.VendorData
+		info.Status = mapStatus(v.Status)
+		info.Result = mapResult(v.Status)
+	}
+	return info, nil
+}
+
+// GenerateLink 创建 session 并返回 Veriff 托管页面链接。
+// Veriff 的 level 与链接有效期在后台 integration 中配置，LevelName / TTL 不生效。
+func (p *Provider) GenerateLink(ctx context.Context, req model.GenerateLinkRequest) (string, error) {
+	if p == nil {
+		return "", errors.New("nil provider")
+	}
+	if strings.TrimSpace(req.UserID) == "" {
+		return "", errors.New("missing user id")
+	}
+
+	callback := strings.TrimSpace(req.SuccessURL)
+	if callback == "" {
+		callback = p.cfg.CallbackURL
+	}
+
+	session, err := p.createSession(ctx, req.UserID, callback)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(session.Verification.URL) == "" {
+		return "", errors.New("empty link")
+	}
+	return session.Verification.URL, nil
+}
+
+func (p *Provider) createSession(ctx context.Context, userID, callback string) (*sessionDTO, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, errors.New("missing user id")
+	}
+
+	body := sessionRequest{}
+	body.Verification.VendorData = userID
+	body.Verification.Callback = callback
+
+	headers := map[string]string{
+		"X-AUTH-CLIENT": p.cfg.APIKey,
+	}
+
+	var resp sessionDTO
+	if err := p.http.PostJSON(ctx, "/v1/sessions", body, headers, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Verification.ID == "" {
+		return nil, errors.New("empty session id")
+	}
+	return &resp, nil
+}
+
+// webhookPayload 同时覆盖 Veriff 的 decision webhook 与 event webhook：
+// decision webhook 携带 verification 对象，event webhook 携带顶层 id / action。
+type webhookPayload struct {
+	// ID / Action / VendorData 来自 event webhook（例如 action=started/submitted）。
+	ID         string `json:"id"`
+	Action     string `json:"action"`
+	VendorData string `json:"vendorData"`
+	// Verification 来自 decision webhook。
+	Verification *struct {
+		ID         string `json:"id"`
+		Status     string `json:"status"`
+		VendorData string `json:"vendorData"`
+		Reason     string `json:"reason"`
+	} `json:"verification"`
+}
+
+// signatureHeader 是 Veriff Webhook 携带签名的 header。
+const signatureHeader = "X-HMAC-SIGNATURE"
+
+// WebhookSignatureHeader 返回 Webhook 签名 header，供 Router 识别回调来源。
+func (p *Provider) WebhookSignatureHeader() string {
+	return signatureHeader
+}
+
+func (p *Provider) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	if p == nil {
+		return nil, errors.New("nil provider")
+	}
+
+	sig := strings.TrimSpace(headers.Get(signatureHeader))
+	if sig == "" {
+		return nil, kycerrors.ErrMissingSignature
+	}
+	if !hmac.Equal([]byte(p.sign(rawBody)), []byte(strings.ToLower(sig))) {
+		return nil, kycerrors.ErrInvalidSignature
+	}
+
+	in := webhookPayload{}
+	if err := json.Unmarshal(rawBody, &in); err != nil {
+		return nil, fmt.Errorf("parse webhook payload: %w", err)
+	}
+
+	if v := in.Verification; v != nil {
+		out := &model.WebhookPayload{
+			Type:           string(model.EventApplicantReviewed),
+			ApplicantID:    v.ID,
+			ExternalUserID: v.VendorData,
+			ReviewStatus:   v.Status,
+			ReviewResult:   mapResult(v.Status),
+		}
+		if v.Reason != "" {
+			out.RejectLabels = []string{v.Reason}
+		}
+		return out, nil
+	}
+
+	return &model.WebhookPayload{
+		Type:           string(mapEventType(in.Action)),
+		ApplicantID:    in.ID,
+		ExternalUserID: in.VendorData,
+		ReviewStatus:   in.Action,
+		ReviewResult:   model.ResultNone,
+	}, nil
+}
+
+func mapEventType(action string) model.WebhookEventType {
+	switch action {
+	case "started":
+		return model.EventApplicantCreated
+	case "submitted":
+		return model.EventApplicantPending
+	default:
+		return model.WebhookEventType(action)
+	}
+}
+
+func mapStatus(s string) model.KycStatus {
+	switch s {
+	case "approved", "declined":
+		return model.StatusReviewed
+	case "resubmission_requested", "review", "submitted", "started", "created":
+		return model.StatusPending
+	default:
+		return model.StatusUnknown
+	}
+}
+
+func mapResult(s string) model.KycResult {
+	switch s {
+	case "approved":
+		return model.ResultGreen
+	case "declined":
+		return model.ResultRed
+	case "resubmission_requested", "review":
+		return model.ResultYellow
+	default:
+		return model.ResultNone
+	}
+}
@@ -3,6 +3,7 @@ package kycerrors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 var (
@@ -10,23 +11,43 @@ var (
 	ErrUnauthorized   = errors.New("kyc-sdk: unauthorized")
 	ErrRateLimited    = errors.New("kyc-sdk: rate limited")
 	ErrBadRequest     = errors.New("kyc-sdk: bad request")
+	ErrNotFound       = errors.New("kyc-sdk: not found")
 	ErrServerInternal = errors.New("kyc-sdk: server internal")
 	ErrUnexpectedHTTP = errors.New("kyc-sdk: unexpected http error")
+	ErrNotSupported   = errors.New("kyc-sdk: operation not supported by provider")
+	// ErrProviderUnavailable 表示熔断器已打开，请求未发出。
+	ErrProviderUnavailable = errors.New("kyc-sdk: provider unavailable")
+	// ErrUnknownTenant 表示 MultiTenant 中没有该租户。
+	ErrUnknownTenant = errors.New("kyc-sdk: unknown tenant")
+	// ErrEnvironmentMismatch 表示凭据或 Webhook 与配置的环境（sandbox / production）不一致。
+	ErrEnvironmentMismatch = errors.New("kyc-sdk: environment mismatch")
+	// ErrSandboxOnly 表示该操作只能在 sandbox 环境中使用。
+	ErrSandboxOnly = errors.New("kyc-sdk: operation is only available in sandbox")
+	// ErrMissingSignature 表示 Webhook 请求未携带签名 header。
+	ErrMissingSignature = errors.New("kyc-sdk: missing webhook signature")
+	// ErrInvalidSignature 表示 Webhook 签名与 body 不匹配。
+	ErrInvalidSignature = errors.New("kyc-sdk: invalid webhook signature")
 )
 
 type HTTPError struct {
 	StatusCode int
 	Body       string
+	RetryAfter time.Duration // 响应的 Retry-After，未携带时为 0
+	RequestID  string        // 调用时通过 client.WithRequestID 设置的请求 ID
 }
 
 func (e *HTTPError) Error() string {
 	if e == nil {
 		return "kyc-sdk: http error"
 	}
-	if e.Body == "" {
-		return fmt.Sprintf("kyc-sdk: http %d", e.StatusCode)
+	msg := fmt.Sprintf("kyc-sdk: http %d", e.StatusCode)
+	if e.Body != "" {
+		msg += ": " + e.Body
 	}
-	return fmt.Sprintf("kyc-sdk: http %d: %s", e.StatusCode, e.Body)
+	if e.RequestID != "" {
+		msg += " (request_id=" + e.RequestID + ")"
+	}
+	return msg
 }
 
 func (e *HTTPError) Is(target error) bool {
@@ -39,6 +60,8 @@ func (e *HTTPError) Is(target error) bool {
 		return e.StatusCode == 400
 	case ErrUnauthorized:
 		return e.StatusCode == 401 || e.StatusCode == 403
+	case ErrNotFound:
+		return e.StatusCode == 404
 	case ErrRateLimited:
 		return e.StatusCode == 429
 	case ErrServerInternal:
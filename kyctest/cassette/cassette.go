@@ -0,0 +1,237 @@
+// Package cassette 提供录制 / 回放 HTTP 交互的 RoundTripper，用于在 CI 中无网络地回放真实 sandbox 请求。
+//
+// 录制时请求经底层 Transport 真实发出，交互在 Stop 时写入 cassette 文件；
+// 凭证类 header（X-App-Token、签名、Authorization 等）与 JSON / 表单中的 PII 字段在写入前被替换为 "REDACTED"。
+// 回放时按 method + path（含 query）+ 脱敏后的 body 匹配请求，忽略 X-App-Access-Ts 等易变 header；
+// 未匹配的请求返回错误。
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dq/kyc-sdk/internal/redact"
+)
+
+// Redacted 是脱敏后的占位值。
+const Redacted = redact.Placeholder
+
+// ErrUnmatched 表示回放时请求没有匹配的录制交互。
+var ErrUnmatched = errors.New("cassette: unmatched request")
+
+type Mode int
+
+const (
+	ModeReplay Mode = iota
+	ModeRecord
+)
+
+// DefaultRedactHeaders 是默认脱敏的 header。
+var DefaultRedactHeaders = redact.DefaultHeaders
+
+// DefaultRedactFields 是默认脱敏的 JSON / 表单字段（按字段名匹配，忽略大小写，任意层级）。
+var DefaultRedactFields = redact.DefaultFields
+
+type Options struct {
+	Mode Mode
+	// Transport 是录制时真实发出请求使用的 RoundTripper，为空时使用 http.DefaultTransport。
+	Transport http.RoundTripper
+	// RedactHeaders / RedactFields 为空时使用 DefaultRedactHeaders / DefaultRedactFields。
+	RedactHeaders []string
+	RedactFields  []string
+	// OnUnmatched 在回放时遇到未匹配的请求时调用（例如 t.Errorf），RoundTrip 仍会返回错误。
+	OnUnmatched func(req *http.Request, err error)
+}
+
+type Request struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder 实现 http.RoundTripper，可通过 config.Config.Transport 接入 SDK。
+type Recorder struct {
+	path     string
+	opts     Options
+	redactor *redact.Redactor
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New 创建 Recorder。回放模式下 path 指向的 cassette 文件必须存在。
+func New(path string, opts Options) (*Recorder, error) {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	if opts.RedactHeaders == nil {
+		opts.RedactHeaders = DefaultRedactHeaders
+	}
+	if opts.RedactFields == nil {
+		opts.RedactFields = DefaultRedactFields
+	}
+
+	r := &Recorder{
+		path:     path,
+		opts:     opts,
+		redactor: redact.New(opts.RedactHeaders, opts.RedactFields),
+	}
+
+	if opts.Mode == ModeReplay {
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read cassette: %w", err)
+		}
+		var c cassetteFile
+		if err := json.Unmarshal(bs, &c); err != nil {
+			return nil, fmt.Errorf("parse cassette %s: %w", path, err)
+		}
+		r.interactions = c.Interactions
+		r.used = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		bs, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = bs
+		req.Body = io.NopCloser(bytes.NewReader(bs))
+	}
+
+	recorded := Request{
+		Method:  req.Method,
+		Path:    r.redactor.URI(req.URL.RequestURI()),
+		Headers: r.redactor.Headers(req.Header),
+		Body:    r.redactor.Body(req.Header.Get("Content-Type"), body),
+	}
+
+	if r.opts.Mode == ModeRecord {
+		return r.record(req, recorded)
+	}
+	return r.replay(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	resp, err := r.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(bs))
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: r.redactor.Headers(resp.Header),
+			Body:    r.redactor.Body(resp.Header.Get("Content-Type"), bs),
+		},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay 返回第一条尚未使用且匹配的交互，同一请求多次出现时按录制顺序依次返回。
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, it := range r.interactions {
+		if r.used[i] || !matches(it.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", it.Response.Status, http.StatusText(it.Response.Status)),
+			StatusCode:    it.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        it.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(it.Response.Body)),
+			ContentLength: int64(len(it.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	err := fmt.Errorf("%w: %s %s (cassette %s)", ErrUnmatched, recorded.Method, recorded.Path, r.path)
+	if r.opts.OnUnmatched != nil {
+		r.opts.OnUnmatched(req, err)
+	}
+	return nil, err
+}
+
+// Stop 在录制模式下把交互写入 cassette 文件（必要时创建目录）；回放模式下不做任何事。
+func (r *Recorder) Stop() error {
+	if r.opts.Mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	c := cassetteFile{Interactions: r.interactions}
+	r.mu.Unlock()
+
+	bs, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(bs, '\n'), 0o644)
+}
+
+// Unused 返回回放模式下尚未被使用的交互。
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []Interaction
+	for i, it := range r.interactions {
+		if i < len(r.used) && !r.used[i] {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+func matches(recorded, got Request) bool {
+	return recorded.Method == got.Method && recorded.Path == got.Path && recorded.Body == got.Body
+}
@@ -0,0 +1,98 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dq/kyc-sdk/client"
+	"github.com/dq/kyc-sdk/kyctest/fakesumsub"
+)
+
+func TestRecorder_RecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sumsub.json")
+
+	srv := fakesumsub.New(fakesumsub.Options{})
+	cfg := srv.Config()
+
+	rec, err := New(path, Options{Mode: ModeRecord})
+	if err != nil {
+		t.Fatalf("New(record): %v", err)
+	}
+	cfg.Transport = rec
+	cli, err := client.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	created, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	if _, err := cli.GenerateLink(context.Background(), client.GenerateLinkRequest{UserID: "user-1", LevelName: "basic", Email: "alice@example.com"}); err != nil {
+		t.Fatalf("GenerateLink: %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	srv.Close()
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+	for _, secret := range []string{fakesumsub.DefaultAppToken, "alice@example.com"} {
+		if strings.Contains(string(bs), secret) {
+			t.Fatalf("cassette leaks %q:\n%s", secret, bs)
+		}
+	}
+
+	// 回放：服务端已关闭，请求只能由 cassette 应答；签名时间戳变化不影响匹配。
+	var unmatched []error
+	replay, err := New(path, Options{OnUnmatched: func(req *http.Request, err error) { unmatched = append(unmatched, err) }})
+	if err != nil {
+		t.Fatalf("New(replay): %v", err)
+	}
+	cfg.Transport = replay
+	cli, err = client.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	got, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("replay CreateApplicant: %v", err)
+	}
+	if got.ApplicantID != created.ApplicantID {
+		t.Fatalf("replayed applicant mismatch: %s vs %s", got.ApplicantID, created.ApplicantID)
+	}
+	if _, err := cli.GenerateLink(context.Background(), client.GenerateLinkRequest{UserID: "user-1", LevelName: "basic", Email: "bob@example.com"}); err != nil {
+		t.Fatalf("replay GenerateLink with different PII: %v", err)
+	}
+	if len(replay.Unused()) != 0 {
+		t.Fatalf("expected all interactions used, got %d unused", len(replay.Unused()))
+	}
+
+	if _, err := cli.GetApplicant(context.Background(), created.ApplicantID); !errors.Is(err, ErrUnmatched) {
+		t.Fatalf("expected ErrUnmatched, got: %v", err)
+	}
+	if len(unmatched) != 1 {
+		t.Fatalf("expected OnUnmatched to be called once, got %d", len(unmatched))
+	}
+}
+
+func TestRecorder_RedactsFormFields(t *testing.T) {
+	r, err := New("", Options{Mode: ModeRecord})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	got := r.redactor.Body("application/x-www-form-urlencoded", []byte("grant_type=client_credentials&client_secret=s3cr3t"))
+	if strings.Contains(got, "s3cr3t") || !strings.Contains(got, "grant_type=client_credentials") {
+		t.Fatalf("unexpected redacted form: %s", got)
+	}
+}
@@ -0,0 +1,37 @@
+package cassette
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// RecordEnv 设置为 1 时 ForTest 以录制模式运行（需要网络与 sandbox 凭证）。
+const RecordEnv = "KYC_CASSETTE_RECORD"
+
+// ForTest 返回绑定到 testdata/cassettes/<name>.json 的 Recorder：
+// 默认回放，未匹配的请求会让测试失败；设置 KYC_CASSETTE_RECORD=1 时改为录制，测试结束时写入文件。
+func ForTest(t testing.TB, name string) *Recorder {
+	t.Helper()
+
+	opts := Options{
+		OnUnmatched: func(req *http.Request, err error) {
+			t.Errorf("%v", err)
+		},
+	}
+	if os.Getenv(RecordEnv) == "1" {
+		opts.Mode = ModeRecord
+	}
+
+	r, err := New(filepath.Join("testdata", "cassettes", name+".json"), opts)
+	if err != nil {
+		t.Fatalf("cassette: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := r.Stop(); err != nil {
+			t.Errorf("cassette: save %s: %v", name, err)
+		}
+	})
+	return r
+}
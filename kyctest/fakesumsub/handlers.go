@@ -0,0 +1,261 @@
+package fakesumsub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// route 分发已通过验签的请求。只实现 SDK 用到的接口及常用的 access token / 文档 / 状态接口。
+func (s *Server) route(w http.ResponseWriter, r *http.Request, body []byte) {
+	path := strings.TrimPrefix(r.URL.Path, "/resources/")
+	if path == r.URL.Path {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	parts := strings.Split(path, "/")
+
+	switch {
+	case r.Method == http.MethodPost && path == "applicants":
+		s.createApplicant(w, r, body)
+	case r.Method == http.MethodPost && path == "sdkIntegrations/levels/-/websdkLink":
+		s.websdkLink(w, body)
+	case r.Method == http.MethodPost && path == "accessTokens":
+		s.accessToken(w, r)
+	case parts[0] == "applicants" && len(parts) >= 2:
+		s.applicantResource(w, r, parts[1], strings.Join(parts[2:], "/"), body)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) applicantResource(w http.ResponseWriter, r *http.Request, id, sub string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.lookupApplicant(id)
+	if a == nil {
+		writeError(w, http.StatusNotFound, "applicant not found")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && (sub == "" || sub == "one"):
+		writeJSON(w, http.StatusOK, a.dto())
+	case r.Method == http.MethodGet && sub == "status":
+		writeJSON(w, http.StatusOK, a.status())
+	case r.Method == http.MethodPost && sub == "status/pending":
+		a.ReviewStatus = "pending"
+		a.ReviewAnswer = ""
+		a.RejectLabels = nil
+		writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+	case r.Method == http.MethodPost && sub == "status/testCompleted":
+		var req struct {
+			ReviewAnswer string   `json:"reviewAnswer"`
+			RejectLabels []string `json:"rejectLabels"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		if req.ReviewAnswer != "GREEN" && req.ReviewAnswer != "RED" {
+			writeError(w, http.StatusBadRequest, "reviewAnswer must be GREEN or RED")
+			return
+		}
+		a.ReviewStatus = "completed"
+		a.ReviewAnswer = req.ReviewAnswer
+		a.RejectLabels = append([]string(nil), req.RejectLabels...)
+		writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+	case r.Method == http.MethodPost && sub == "info/idDoc":
+		doc, err := parseIDDoc(r, body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		a.Documents = append(a.Documents, doc)
+		w.Header().Set("X-Image-Id", fmt.Sprintf("%d", len(a.Documents)))
+		writeJSON(w, http.StatusOK, map[string]string{
+			"idDocType":    doc.IDDocType,
+			"idDocSubType": doc.IDDocSubType,
+			"country":      doc.Country,
+		})
+	case r.Method == http.MethodGet && sub == "requiredIdDocsStatus":
+		out := make(map[string]any, len(a.Documents))
+		for _, d := range a.Documents {
+			entry := map[string]any{"imageIds": []int{}}
+			if a.ReviewAnswer != "" {
+				entry["reviewResult"] = a.reviewResult()
+			}
+			out[d.IDDocType] = entry
+		}
+		writeJSON(w, http.StatusOK, out)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// lookupApplicant 支持 applicant ID 与 "-;externalUserId=<id>" 两种寻址方式；调用方需持有 s.mu。
+func (s *Server) lookupApplicant(ref string) *applicant {
+	if ext, ok := strings.CutPrefix(ref, "-;externalUserId="); ok {
+		ref = s.byUser[ext]
+	}
+	return s.applicants[ref]
+}
+
+func (s *Server) createApplicant(w http.ResponseWriter, r *http.Request, body []byte) {
+	var in struct {
+		ExternalUserID string          `json:"externalUserId"`
+		Type           string          `json:"type"`
+		Info           json.RawMessage `json:"info"`
+	}
+	if err := json.Unmarshal(body, &in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+	if strings.TrimSpace(in.ExternalUserID) == "" {
+		writeError(w, http.StatusBadRequest, "externalUserId required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.byUser[in.ExternalUserID]; exists {
+		writeError(w, http.StatusConflict, "Applicant with external user id '"+in.ExternalUserID+"' already exists")
+		return
+	}
+
+	a := s.newApplicant(in.ExternalUserID, r.URL.Query().Get("levelName"))
+	if in.Type != "" {
+		a.Type = in.Type
+	}
+	a.info = in.Info
+	writeJSON(w, http.StatusCreated, a.dto())
+}
+
+func (s *Server) websdkLink(w http.ResponseWriter, body []byte) {
+	var in struct {
+		LevelName      string `json:"levelName"`
+		ExternalUserID string `json:"externalUserId"`
+		TTLInSecs      int    `json:"ttlInSecs"`
+	}
+	if err := json.Unmarshal(body, &in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+	if in.LevelName == "" || in.ExternalUserID == "" {
+		writeError(w, http.StatusBadRequest, "levelName and externalUserId required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.byUser[in.ExternalUserID]; !exists {
+		s.newApplicant(in.ExternalUserID, in.LevelName)
+	}
+	s.seq++
+	writeJSON(w, http.StatusOK, map[string]string{"url": fmt.Sprintf("%s/websdk/p/sbx_%08d", s.URL, s.seq)})
+}
+
+func (s *Server) accessToken(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID := q.Get("userId")
+	if userID == "" || q.Get("levelName") == "" {
+		writeError(w, http.StatusBadRequest, "userId and levelName required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	writeJSON(w, http.StatusOK, map[string]string{
+		"token":  fmt.Sprintf("_act-sbx-%08d", s.seq),
+		"userId": userID,
+	})
+}
+
+// newApplicant 创建 applicant；调用方需持有 s.mu。
+func (s *Server) newApplicant(externalUserID, levelName string) *applicant {
+	s.seq++
+	a := &applicant{Applicant: Applicant{
+		ID:             fmt.Sprintf("%024x", s.seq),
+		ExternalUserID: externalUserID,
+		LevelName:      levelName,
+		Type:           "individual",
+		ReviewStatus:   "init",
+	}}
+	s.applicants[a.ID] = a
+	s.byUser[externalUserID] = a.ID
+	return a
+}
+
+func (a *applicant) dto() map[string]any {
+	out := map[string]any{
+		"id":             a.ID,
+		"externalUserId": a.ExternalUserID,
+		"levelName":      a.LevelName,
+		"type":           a.Type,
+		"review":         a.status(),
+	}
+	if len(a.info) > 0 {
+		out["info"] = a.info
+	}
+	return out
+}
+
+func (a *applicant) status() map[string]any {
+	out := map[string]any{"reviewStatus": a.ReviewStatus}
+	if a.ReviewAnswer != "" {
+		out["reviewResult"] = a.reviewResult()
+	}
+	return out
+}
+
+// parseIDDoc 解析 multipart 上传：metadata 部分为 JSON，content 部分为文件内容。
+func parseIDDoc(r *http.Request, body []byte) (Document, error) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || params["boundary"] == "" {
+		return Document{}, fmt.Errorf("multipart body required")
+	}
+
+	var doc Document
+	mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Document{}, fmt.Errorf("read multipart: %w", err)
+		}
+
+		switch part.FormName() {
+		case "metadata":
+			var meta struct {
+				IDDocType    string `json:"idDocType"`
+				IDDocSubType string `json:"idDocSubType"`
+				Country      string `json:"country"`
+			}
+			if err := json.NewDecoder(part).Decode(&meta); err != nil {
+				return Document{}, fmt.Errorf("invalid metadata: %w", err)
+			}
+			doc.IDDocType, doc.IDDocSubType, doc.Country = meta.IDDocType, meta.IDDocSubType, meta.Country
+		case "content":
+			n, err := io.Copy(io.Discard, part)
+			if err != nil {
+				return Document{}, fmt.Errorf("read content: %w", err)
+			}
+			doc.FileName = part.FileName()
+			doc.Size = int(n)
+		}
+	}
+
+	if doc.IDDocType == "" || doc.Country == "" {
+		return Document{}, fmt.Errorf("metadata.idDocType and metadata.country required")
+	}
+	return doc, nil
+}
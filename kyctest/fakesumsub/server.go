@@ -0,0 +1,362 @@
+// Package fakesumsub 提供一个基于 httptest 的 Sumsub 假服务端，用于契约级别的测试。
+//
+// 服务端按与 SDK 相同的算法校验 X-App-Token / X-App-Access-Ts / X-App-Access-Sig，
+// 在内存中维护 applicant、文档与审核状态，并支持注入故障（429 + Retry-After、5xx、延迟）
+// 以及向回调地址发送已签名的 Webhook。
+package fakesumsub
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/internal/signer"
+	"github.com/dq/kyc-sdk/model"
+)
+
+const (
+	DefaultAppToken      = "fake-app-token"
+	DefaultSecretKey     = "fake-secret-key"
+	DefaultWebhookSecret = "fake-webhook-secret"
+)
+
+type Options struct {
+	AppToken      string // 为空时使用 DefaultAppToken
+	SecretKey     string // 为空时使用 DefaultSecretKey
+	WebhookSecret string // 为空时使用 DefaultWebhookSecret
+	CallbackURL   string // FireWebhook 的目标地址，也可以之后通过 SetCallbackURL 设置
+	// MaxClockSkew 是 X-App-Access-Ts 与服务端时间允许的最大偏差，0 表示 5 分钟。
+	MaxClockSkew time.Duration
+}
+
+// Fault 描述一次注入的故障。Method / PathPrefix 为空表示匹配所有请求。
+type Fault struct {
+	Method     string
+	PathPrefix string
+	Status     int           // 返回的状态码；0 表示只注入延迟，请求照常处理
+	RetryAfter time.Duration // Status 为 429 时写入 Retry-After（按秒取整）
+	Latency    time.Duration // 处理请求前的延迟，客户端取消时提前结束
+	Times      int           // 生效次数；0 表示一直生效直到 ClearFaults
+}
+
+// Document 是通过 /info/idDoc 上传的文档。
+type Document struct {
+	IDDocType    string
+	IDDocSubType string
+	Country      string
+	FileName     string
+	Size         int
+}
+
+// Applicant 是服务端保存的 applicant 状态快照。
+type Applicant struct {
+	ID             string
+	ExternalUserID string
+	LevelName      string
+	Type           string
+	ReviewStatus   string // init / pending / completed
+	ReviewAnswer   string // GREEN / RED / YELLOW，未审核时为空
+	RejectLabels   []string
+	Documents      []Document
+}
+
+// Request 是服务端收到并通过验签的请求记录。
+type Request struct {
+	Method string
+	Path   string // 包含 query
+	Body   []byte
+}
+
+type applicant struct {
+	Applicant
+	info json.RawMessage
+}
+
+type Server struct {
+	*httptest.Server
+
+	opts Options
+	now  func() time.Time
+
+	mu          sync.Mutex
+	seq         int
+	applicants  map[string]*applicant
+	byUser      map[string]string
+	faults      []*Fault
+	requests    []Request
+	callbackURL string
+}
+
+// New 启动一个假 Sumsub 服务端，使用完毕后需要调用 Close。
+func New(opts Options) *Server {
+	if opts.AppToken == "" {
+		opts.AppToken = DefaultAppToken
+	}
+	if opts.SecretKey == "" {
+		opts.SecretKey = DefaultSecretKey
+	}
+	if opts.WebhookSecret == "" {
+		opts.WebhookSecret = DefaultWebhookSecret
+	}
+	if opts.MaxClockSkew <= 0 {
+		opts.MaxClockSkew = 5 * time.Minute
+	}
+
+	s := &Server{
+		opts:        opts,
+		now:         time.Now,
+		applicants:  make(map[string]*applicant),
+		byUser:      make(map[string]string),
+		callbackURL: opts.CallbackURL,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Config 返回指向该服务端的 Sumsub 配置，可直接传给 client.NewClient。
+func (s *Server) Config() *config.Config {
+	return &config.Config{
+		Provider:      "sumsub",
+		BaseURL:       s.URL,
+		AppToken:      s.opts.AppToken,
+		SecretKey:     s.opts.SecretKey,
+		WebhookSecret: s.opts.WebhookSecret,
+	}
+}
+
+func (s *Server) SetCallbackURL(u string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callbackURL = u
+}
+
+// InjectFault 追加一个故障，多个故障按注入顺序匹配，每个请求最多命中一个。
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests 返回通过验签的请求记录（按到达顺序）。
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) Applicant(applicantID string) (Applicant, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.applicants[applicantID]
+	if !ok {
+		return Applicant{}, false
+	}
+	return a.snapshot(), true
+}
+
+// Review 模拟审核完成，answer 为 GREEN / RED / YELLOW。
+func (s *Server) Review(applicantID string, answer model.KycResult, rejectLabels ...string) error {
+	switch answer {
+	case model.ResultGreen, model.ResultRed, model.ResultYellow:
+	default:
+		return fmt.Errorf("unsupported review answer %q", answer)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.applicants[applicantID]
+	if !ok {
+		return fmt.Errorf("applicant %s not found", applicantID)
+	}
+	a.ReviewStatus = "completed"
+	a.ReviewAnswer = string(answer)
+	a.RejectLabels = append([]string(nil), rejectLabels...)
+	return nil
+}
+
+// FireWebhook 按 applicant 当前状态向回调地址发送一条 X-Payload-Digest 签名的 Webhook，
+// 回调返回非 2xx 时返回错误。
+func (s *Server) FireWebhook(ctx context.Context, event model.WebhookEventType, applicantID string) error {
+	s.mu.Lock()
+	target := s.callbackURL
+	a, ok := s.applicants[applicantID]
+	var body []byte
+	var err error
+	if ok {
+		body, err = json.Marshal(a.webhook(event))
+	}
+	s.mu.Unlock()
+
+	if target == "" {
+		return fmt.Errorf("callback url not configured")
+	}
+	if !ok {
+		return fmt.Errorf("applicant %s not found", applicantID)
+	}
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(s.opts.WebhookSecret))
+	mac.Write(body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Payload-Digest", hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-Payload-Digest-Alg", "HMAC_SHA256_HEX")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if f := s.takeFault(r); f != nil {
+		if f.Latency > 0 {
+			select {
+			case <-time.After(f.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if f.Status != 0 {
+			if f.Status == http.StatusTooManyRequests && f.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int((f.RetryAfter+time.Second-1)/time.Second)))
+			}
+			writeError(w, f.Status, "injected fault")
+			return
+		}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "read body: "+err.Error())
+		return
+	}
+	if msg := s.authenticate(r, body); msg != "" {
+		writeError(w, http.StatusUnauthorized, msg)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.RequestURI(), Body: body})
+	s.mu.Unlock()
+
+	s.route(w, r, body)
+}
+
+// authenticate 按 signer.Signature 的算法校验请求签名，失败时返回错误描述。
+func (s *Server) authenticate(r *http.Request, body []byte) string {
+	if r.Header.Get("X-App-Token") != s.opts.AppToken {
+		return "invalid X-App-Token"
+	}
+
+	ts := r.Header.Get("X-App-Access-Ts")
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return "invalid X-App-Access-Ts"
+	}
+	if d := s.now().Sub(time.Unix(sec, 0)); d > s.opts.MaxClockSkew || d < -s.opts.MaxClockSkew {
+		return "X-App-Access-Ts outside allowed clock skew"
+	}
+
+	want := signer.Signature(s.opts.SecretKey, ts, r.Method, r.URL.RequestURI(), body)
+	if !hmac.Equal([]byte(want), []byte(strings.ToLower(r.Header.Get("X-App-Access-Sig")))) {
+		return "invalid X-App-Access-Sig"
+	}
+	return ""
+}
+
+func (s *Server) takeFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+			continue
+		}
+		if f.PathPrefix != "" && !strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		out := *f
+		return &out
+	}
+	return nil
+}
+
+func (a *applicant) snapshot() Applicant {
+	out := a.Applicant
+	out.RejectLabels = append([]string(nil), a.RejectLabels...)
+	out.Documents = append([]Document(nil), a.Documents...)
+	return out
+}
+
+func (a *applicant) webhook(event model.WebhookEventType) map[string]any {
+	out := map[string]any{
+		"type":           string(event),
+		"applicantId":    a.ID,
+		"externalUserId": a.ExternalUserID,
+		"levelName":      a.LevelName,
+		"reviewStatus":   a.ReviewStatus,
+	}
+	if a.ReviewAnswer != "" {
+		out["reviewResult"] = a.reviewResult()
+	}
+	return out
+}
+
+func (a *applicant) reviewResult() map[string]any {
+	out := map[string]any{"reviewAnswer": a.ReviewAnswer}
+	if len(a.RejectLabels) > 0 {
+		out["rejectLabels"] = a.RejectLabels
+	}
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError 按 Sumsub 的错误格式返回。
+func writeError(w http.ResponseWriter, status int, description string) {
+	writeJSON(w, status, map[string]any{
+		"code":          status,
+		"description":   description,
+		"correlationId": fmt.Sprintf("fake-%d", time.Now().UnixNano()),
+	})
+}
@@ -0,0 +1,235 @@
+package fakesumsub
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dq/kyc-sdk/client"
+	"github.com/dq/kyc-sdk/internal/signer"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+func TestServer_ApplicantAndLinkFlow(t *testing.T) {
+	srv := New(Options{})
+	defer srv.Close()
+
+	cli, err := client.NewClient(srv.Config())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	info, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	if info.UserID != "user-1" || info.ApplicantID == "" {
+		t.Fatalf("applicant mismatch: %+v", info)
+	}
+
+	var httpErr *kycerrors.HTTPError
+	if _, err := cli.CreateApplicant(context.Background(), "user-1"); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409 for duplicate externalUserId, got: %v", err)
+	}
+
+	link, err := cli.GenerateLink(context.Background(), client.GenerateLinkRequest{UserID: "user-1", LevelName: "basic"})
+	if err != nil || link == "" {
+		t.Fatalf("GenerateLink: %q %v", link, err)
+	}
+
+	if err := srv.Review(info.ApplicantID, model.ResultGreen); err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+	got, err := cli.GetApplicant(context.Background(), info.ApplicantID)
+	if err != nil {
+		t.Fatalf("GetApplicant: %v", err)
+	}
+	if got.Status != model.StatusReviewed || got.Result != model.ResultGreen {
+		t.Fatalf("unexpected review state: %+v", got)
+	}
+
+	if _, err := cli.GetApplicant(context.Background(), "missing"); !errors.Is(err, kycerrors.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	if n := len(srv.Requests()); n != 5 {
+		t.Fatalf("expected 5 recorded requests, got %d", n)
+	}
+}
+
+func TestServer_RejectsBadSignature(t *testing.T) {
+	srv := New(Options{})
+	defer srv.Close()
+
+	cfg := srv.Config()
+	cfg.SecretKey = "wrong"
+	cli, err := client.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := cli.CreateApplicant(context.Background(), "user-1"); !errors.Is(err, kycerrors.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got: %v", err)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	srv := New(Options{})
+	defer srv.Close()
+
+	cli, err := client.NewClient(srv.Config())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	srv.InjectFault(Fault{Status: http.StatusTooManyRequests, RetryAfter: 2 * time.Second, Times: 1})
+	if _, err := cli.CreateApplicant(context.Background(), "user-1"); !errors.Is(err, kycerrors.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got: %v", err)
+	}
+
+	srv.InjectFault(Fault{Method: http.MethodGet, PathPrefix: "/resources/applicants/", Status: http.StatusServiceUnavailable})
+	info, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant after one-shot fault: %v", err)
+	}
+	if _, err := cli.GetApplicant(context.Background(), info.ApplicantID); !errors.Is(err, kycerrors.ErrServerInternal) {
+		t.Fatalf("expected ErrServerInternal, got: %v", err)
+	}
+	srv.ClearFaults()
+
+	srv.InjectFault(Fault{Latency: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := cli.GetApplicant(ctx, info.ApplicantID); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got: %v", err)
+	}
+	srv.ClearFaults()
+
+	srv.InjectFault(Fault{Status: http.StatusTooManyRequests, RetryAfter: 1500 * time.Millisecond, Times: 1})
+	resp, err := http.Post(srv.URL+"/resources/applicants", "application/json", nil)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "2" {
+		t.Fatalf("unexpected fault response: %d Retry-After=%q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+}
+
+func TestServer_DocumentsAndStatus(t *testing.T) {
+	srv := New(Options{})
+	defer srv.Close()
+
+	cli, err := client.NewClient(srv.Config())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	info, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	_ = mw.WriteField("metadata", `{"idDocType":"PASSPORT","country":"GBR"}`)
+	fw, _ := mw.CreateFormFile("content", "passport.jpg")
+	_, _ = fw.Write([]byte("jpeg-bytes"))
+	_ = mw.Close()
+
+	path := "/resources/applicants/" + info.ApplicantID + "/info/idDoc"
+	resp := signedDo(t, srv, http.MethodPost, path, mw.FormDataContentType(), buf.Bytes())
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("upload idDoc: %d", resp.StatusCode)
+	}
+
+	resp = signedDo(t, srv, http.MethodPost, "/resources/accessTokens?userId=user-1&levelName=basic", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("access token: %d", resp.StatusCode)
+	}
+
+	a, ok := srv.Applicant(info.ApplicantID)
+	if !ok || len(a.Documents) != 1 || a.Documents[0].IDDocType != "PASSPORT" || a.Documents[0].Size != len("jpeg-bytes") {
+		t.Fatalf("documents mismatch: %+v", a.Documents)
+	}
+
+	resp = signedDo(t, srv, http.MethodPost, "/resources/applicants/"+info.ApplicantID+"/status/pending", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status/pending: %d", resp.StatusCode)
+	}
+	got, err := cli.GetApplicant(context.Background(), info.ApplicantID)
+	if err != nil || got.Status != model.StatusPending {
+		t.Fatalf("expected pending applicant, got %+v %v", got, err)
+	}
+}
+
+func TestServer_FireWebhook(t *testing.T) {
+	srv := New(Options{WebhookSecret: "wh"})
+	defer srv.Close()
+
+	cli, err := client.NewClient(srv.Config())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	received := make(chan *model.WebhookPayload, 1)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		payload, err := cli.VerifyAndParseWebhook(r.Header, raw)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		received <- payload
+	}))
+	defer callback.Close()
+	srv.SetCallbackURL(callback.URL)
+
+	info, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	if err := srv.Review(info.ApplicantID, model.ResultRed, "FORGERY"); err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+	if err := srv.FireWebhook(context.Background(), model.EventApplicantReviewed, info.ApplicantID); err != nil {
+		t.Fatalf("FireWebhook: %v", err)
+	}
+
+	payload := <-received
+	if payload.EventType() != model.EventApplicantReviewed || payload.ApplicantID != info.ApplicantID || payload.ReviewResult != model.ResultRed || len(payload.RejectLabels) != 1 {
+		t.Fatalf("payload mismatch: %+v", payload)
+	}
+}
+
+func signedDo(t *testing.T, srv *Server, method, path, contentType string, body []byte) *http.Response {
+	t.Helper()
+
+	headers, err := signer.New(DefaultAppToken, DefaultSecretKey).Sign(method, path, nil)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	headers["X-App-Access-Sig"] = signer.Signature(DefaultSecretKey, headers["X-App-Access-Ts"], method, path, body)
+
+	req, err := http.NewRequest(method, srv.URL+path, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	resp.Body.Close()
+	return resp
+}
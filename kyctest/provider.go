@@ -0,0 +1,280 @@
+// Package kyctest 提供完全基于内存的 Provider，用于本地开发与集成测试，不会发起任何网络请求。
+//
+// 生成的 Webhook 使用 Sumsub 的格式与 X-Payload-Digest 签名，
+// 因此既可以交给 kyctest.Provider 自身解析，也可以交给以相同 WebhookSecret 配置的 Sumsub Client 解析。
+package kyctest
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/model"
+)
+
+// DefaultWebhookSecret 是 New 传入空 secret 时使用的 Webhook secret。
+const DefaultWebhookSecret = "kyctest-webhook-secret"
+
+// LinkBaseURL 是 GenerateLink 返回的假链接前缀。
+const LinkBaseURL = "https://kyctest.local/verify"
+
+type applicant struct {
+	info         model.ApplicantInfo
+	rejectLabels []string
+}
+
+// Provider 是内存中的 client.Provider 实现，可安全地并发使用。
+type Provider struct {
+	secret string
+
+	mu         sync.Mutex
+	seq        int
+	applicants map[string]*applicant
+	byUser     map[string]string
+}
+
+// New 创建一个空的 Provider，webhookSecret 为空时使用 DefaultWebhookSecret。
+func New(webhookSecret string) *Provider {
+	if webhookSecret == "" {
+		webhookSecret = DefaultWebhookSecret
+	}
+	return &Provider{
+		secret:     webhookSecret,
+		applicants: make(map[string]*applicant),
+		byUser:     make(map[string]string),
+	}
+}
+
+// WebhookSecret 返回签名 Webhook 使用的 secret。
+func (p *Provider) WebhookSecret() string {
+	return p.secret
+}
+
+// CreateApplicant 为 userID 创建 applicant；同一 userID 重复创建时返回已有 applicant。
+func (p *Provider) CreateApplicant(ctx context.Context, userID string) (*model.ApplicantInfo, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, fmt.Errorf("%w: userID required", kycerrors.ErrBadRequest)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	info := p.ensureApplicant(userID).info
+	return &info, nil
+}
+
+// Environment 固定为 sandbox，使 Client 允许调用仅限 sandbox 的操作。
+func (p *Provider) Environment() config.Environment {
+	return config.EnvSandbox
+}
+
+func (p *Provider) GetApplicant(ctx context.Context, applicantID string) (*model.ApplicantInfo, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	a, ok := p.applicants[applicantID]
+	if !ok {
+		return nil, fmt.Errorf("applicant %s: %w", applicantID, kycerrors.ErrNotFound)
+	}
+	info := a.info
+	return &info, nil
+}
+
+// GenerateLink 返回 LinkBaseURL 下的假链接。与 Sumsub WebSDK 一致，用户还没有 applicant 时会自动创建。
+func (p *Provider) GenerateLink(ctx context.Context, req model.GenerateLinkRequest) (string, error) {
+	if strings.TrimSpace(req.UserID) == "" {
+		return "", fmt.Errorf("%w: UserID required", kycerrors.ErrBadRequest)
+	}
+
+	p.mu.Lock()
+	applicantID := p.ensureApplicant(req.UserID).info.ApplicantID
+	p.mu.Unlock()
+
+	q := url.Values{}
+	q.Set("applicantId", applicantID)
+	if req.LevelName != "" {
+		q.Set("levelName", req.LevelName)
+	}
+	if req.SuccessURL != "" {
+		q.Set("successUrl", req.SuccessURL)
+	}
+	if req.RejectURL != "" {
+		q.Set("rejectUrl", req.RejectURL)
+	}
+	return LinkBaseURL + "/" + url.PathEscape(req.UserID) + "?" + q.Encode(), nil
+}
+
+// Review 模拟审核完成：把 applicant 置为 REVIEWED，并记录审核结论与拒绝原因。
+func (p *Provider) Review(applicantID string, result model.KycResult, rejectLabels ...string) error {
+	switch result {
+	case model.ResultGreen, model.ResultRed, model.ResultYellow:
+	default:
+		return fmt.Errorf("%w: unsupported review result %q", kycerrors.ErrBadRequest, result)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	a, ok := p.applicants[applicantID]
+	if !ok {
+		return fmt.Errorf("applicant %s: %w", applicantID, kycerrors.ErrNotFound)
+	}
+	a.info.Status = model.StatusReviewed
+	a.info.Result = result
+	a.rejectLabels = append([]string(nil), rejectLabels...)
+	return nil
+}
+
+// SimulateReview 实现 client.ReviewSimulator，等同于 Review。
+func (p *Provider) SimulateReview(_ context.Context, applicantID string, answer model.KycResult, rejectLabels []string) error {
+	return p.Review(applicantID, answer, rejectLabels...)
+}
+
+// Reset 把 applicant 退回 PENDING（模拟用户重新提交资料）。
+func (p *Provider) Reset(applicantID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	a, ok := p.applicants[applicantID]
+	if !ok {
+		return fmt.Errorf("applicant %s: %w", applicantID, kycerrors.ErrNotFound)
+	}
+	a.info.Status = model.StatusPending
+	a.info.Result = model.ResultNone
+	a.rejectLabels = nil
+	return nil
+}
+
+// Webhook 按 applicant 当前状态生成一条 Sumsub 格式的回调，返回已签名的 header 与 body，
+// 可以直接用 httptest 发给业务的 Webhook handler。
+func (p *Provider) Webhook(event model.WebhookEventType, applicantID string) (http.Header, []byte, error) {
+	p.mu.Lock()
+	a, ok := p.applicants[applicantID]
+	if !ok {
+		p.mu.Unlock()
+		return nil, nil, fmt.Errorf("applicant %s: %w", applicantID, kycerrors.ErrNotFound)
+	}
+	in := webhookPayload{
+		Type:           string(event),
+		ApplicantID:    a.info.ApplicantID,
+		ExternalUserID: a.info.UserID,
+		ReviewStatus:   reviewStatus(a.info.Status),
+	}
+	if a.info.Status == model.StatusReviewed {
+		in.ReviewResult = &reviewResult{ReviewAnswer: string(a.info.Result), RejectLabels: a.rejectLabels}
+	}
+	p.mu.Unlock()
+
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	h := http.Header{}
+	h.Set("Content-Type", "application/json")
+	h.Set("X-Payload-Digest", p.sign(body))
+	h.Set("X-Payload-Digest-Alg", "HMAC_SHA256_HEX")
+	return h, body, nil
+}
+
+// NewWebhookRequest 与 Webhook 相同，但直接返回一个 POST 到 target 的 *http.Request。
+func (p *Provider) NewWebhookRequest(target string, event model.WebhookEventType, applicantID string) (*http.Request, error) {
+	h, body, err := p.Webhook(event, applicantID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = h
+	return req, nil
+}
+
+// WebhookSignatureHeader 与 Sumsub 相同，为 X-Payload-Digest。
+func (p *Provider) WebhookSignatureHeader() string {
+	return "X-Payload-Digest"
+}
+
+func (p *Provider) VerifyAndParseWebhook(headers http.Header, rawBody []byte) (*model.WebhookPayload, error) {
+	sig := strings.TrimSpace(headers.Get("X-Payload-Digest"))
+	if sig == "" {
+		return nil, kycerrors.ErrMissingSignature
+	}
+	if !hmac.Equal([]byte(p.sign(rawBody)), []byte(sig)) {
+		return nil, kycerrors.ErrInvalidSignature
+	}
+
+	in := webhookPayload{}
+	if err := json.Unmarshal(rawBody, &in); err != nil {
+		return nil, fmt.Errorf("parse webhook payload: %w", err)
+	}
+
+	out := &model.WebhookPayload{
+		Type:           in.Type,
+		ApplicantID:    in.ApplicantID,
+		ExternalUserID: in.ExternalUserID,
+		ReviewStatus:   in.ReviewStatus,
+		ReviewResult:   model.ResultNone,
+	}
+	if in.ReviewResult != nil {
+		out.ReviewResult = model.KycResult(in.ReviewResult.ReviewAnswer)
+		out.RejectLabels = in.ReviewResult.RejectLabels
+	}
+	return out, nil
+}
+
+// ensureApplicant 返回 userID 对应的 applicant，不存在时创建；调用方需持有 p.mu。
+func (p *Provider) ensureApplicant(userID string) *applicant {
+	if id, ok := p.byUser[userID]; ok {
+		return p.applicants[id]
+	}
+
+	p.seq++
+	a := &applicant{info: model.ApplicantInfo{
+		UserID:      userID,
+		ApplicantID: fmt.Sprintf("kyctest-%d", p.seq),
+		Status:      model.StatusPending,
+		Result:      model.ResultNone,
+		Provider:    "kyctest",
+	}}
+	p.applicants[a.info.ApplicantID] = a
+	p.byUser[userID] = a.info.ApplicantID
+	return a
+}
+
+func (p *Provider) sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type reviewResult struct {
+	ReviewAnswer string   `json:"reviewAnswer"`
+	RejectLabels []string `json:"rejectLabels,omitempty"`
+}
+
+// webhookPayload 与 Sumsub 回调的字段保持一致。
+type webhookPayload struct {
+	Type           string        `json:"type"`
+	ApplicantID    string        `json:"applicantId"`
+	ExternalUserID string        `json:"externalUserId"`
+	ReviewStatus   string        `json:"reviewStatus"`
+	ReviewResult   *reviewResult `json:"reviewResult,omitempty"`
+}
+
+func reviewStatus(s model.KycStatus) string {
+	if s == model.StatusReviewed {
+		return "completed"
+	}
+	return "pending"
+}
@@ -0,0 +1,112 @@
+package kyctest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dq/kyc-sdk/client"
+	"github.com/dq/kyc-sdk/config"
+	"github.com/dq/kyc-sdk/kycerrors"
+	"github.com/dq/kyc-sdk/kyctest"
+	"github.com/dq/kyc-sdk/model"
+)
+
+var _ client.Provider = (*kyctest.Provider)(nil)
+
+func TestProvider_ApplicantLifecycle(t *testing.T) {
+	p := kyctest.New("")
+	cli, err := client.New(p)
+	if err != nil {
+		t.Fatalf("client.New: %v", err)
+	}
+
+	link, err := cli.GenerateLink(context.Background(), client.GenerateLinkRequest{UserID: "user-1", LevelName: "basic"})
+	if err != nil {
+		t.Fatalf("GenerateLink: %v", err)
+	}
+	if !strings.HasPrefix(link, kyctest.LinkBaseURL+"/user-1?") {
+		t.Fatalf("unexpected link: %s", link)
+	}
+
+	info, err := cli.CreateApplicant(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("CreateApplicant: %v", err)
+	}
+	if info.Status != model.StatusPending || info.Result != model.ResultNone {
+		t.Fatalf("unexpected initial state: %+v", info)
+	}
+	if !strings.Contains(link, "applicantId="+info.ApplicantID) {
+		t.Fatalf("link and applicant mismatch: %s vs %s", link, info.ApplicantID)
+	}
+
+	if err := p.Review(info.ApplicantID, model.ResultRed, "FORGERY"); err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+	got, err := cli.GetApplicant(context.Background(), info.ApplicantID)
+	if err != nil {
+		t.Fatalf("GetApplicant: %v", err)
+	}
+	if got.Status != model.StatusReviewed || got.Result != model.ResultRed {
+		t.Fatalf("unexpected reviewed state: %+v", got)
+	}
+
+	if _, err := cli.GetApplicant(context.Background(), "missing"); !errors.Is(err, kycerrors.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	if err := p.Review(info.ApplicantID, model.ResultNone); !errors.Is(err, kycerrors.ErrBadRequest) {
+		t.Fatalf("expected ErrBadRequest, got: %v", err)
+	}
+}
+
+func TestProvider_WebhookIsAcceptedBySumsubClient(t *testing.T) {
+	p := kyctest.New("shared-secret")
+	info, _ := p.CreateApplicant(context.Background(), "user-1")
+	if err := p.Review(info.ApplicantID, model.ResultGreen); err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+
+	sumsubCli, err := client.NewClient(&config.Config{BaseURL: "http://unused", AppToken: "t", SecretKey: "s", WebhookSecret: "shared-secret"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var got *model.WebhookPayload
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		payload, err := sumsubCli.VerifyAndParseWebhook(r.Header, raw)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		got = payload
+	})
+
+	req, err := p.NewWebhookRequest("/webhook", model.EventApplicantReviewed, info.ApplicantID)
+	if err != nil {
+		t.Fatalf("NewWebhookRequest: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("handler rejected webhook: %d %s", rec.Code, rec.Body.String())
+	}
+	if got.EventType() != model.EventApplicantReviewed || got.ApplicantID != info.ApplicantID || got.ExternalUserID != "user-1" || got.ReviewResult != model.ResultGreen {
+		t.Fatalf("payload mismatch: %+v", got)
+	}
+
+	h, body, err := p.Webhook(model.EventApplicantReviewed, info.ApplicantID)
+	if err != nil {
+		t.Fatalf("Webhook: %v", err)
+	}
+	if _, err := p.VerifyAndParseWebhook(h, body); err != nil {
+		t.Fatalf("VerifyAndParseWebhook: %v", err)
+	}
+	if _, err := kyctest.New("other").VerifyAndParseWebhook(h, body); err == nil {
+		t.Fatalf("expected error for invalid signature")
+	}
+}
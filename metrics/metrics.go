@@ -0,0 +1,58 @@
+// Package metrics 定义 SDK 的指标接口，prommetrics 子包提供 Prometheus 实现。
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/dq/kyc-sdk/model"
+)
+
+// WebhookOutcome 是一次 Webhook 验签的结果。
+type WebhookOutcome string
+
+const (
+	WebhookOK        WebhookOutcome = "ok"
+	WebhookMissing   WebhookOutcome = "missing"   // 缺少签名 header
+	WebhookInvalid   WebhookOutcome = "invalid"   // 签名不匹配或时间戳超出容忍范围
+	WebhookDuplicate WebhookOutcome = "duplicate" // 验签通过，但与最近收到的回调重复（Provider 重试）
+	WebhookError     WebhookOutcome = "error"     // 配置错误、body 解析失败等
+)
+
+// Recorder 接收 SDK 产生的指标，实现需要并发安全。
+type Recorder interface {
+	// ObserveRequest 记录一次 Client 方法调用，statusClass 为 2xx / 4xx / 5xx / error。
+	ObserveRequest(provider, operation, statusClass string, latency time.Duration)
+	// IncRateLimited 记录一次 Provider 返回 429。
+	IncRateLimited(provider, operation string)
+	// IncRetry 记录一次重试（第 2 次及之后的 HTTP 尝试）。
+	IncRetry(provider, operation string)
+	ObserveWebhook(provider string, outcome WebhookOutcome)
+	IncWebhookEvent(provider string, eventType model.WebhookEventType)
+	// IncReviewResult 记录 Webhook 送达的审核结论（GREEN / RED / YELLOW）。
+	IncReviewResult(provider string, result model.KycResult)
+}
+
+// TenantRecorder 是可以按租户区分指标的 Recorder，client.MultiTenant 为每个租户调用 WithTenant。
+type TenantRecorder interface {
+	Recorder
+	WithTenant(tenant string) Recorder
+}
+
+// Nop 丢弃所有指标。
+type Nop struct{}
+
+func (Nop) ObserveRequest(provider, operation, statusClass string, latency time.Duration) {}
+func (Nop) IncRateLimited(provider, operation string)                                     {}
+func (Nop) IncRetry(provider, operation string)                                           {}
+func (Nop) ObserveWebhook(provider string, outcome WebhookOutcome)                        {}
+func (Nop) IncWebhookEvent(provider string, eventType model.WebhookEventType)             {}
+func (Nop) IncReviewResult(provider string, result model.KycResult)                       {}
+
+// StatusClass 把 HTTP 状态码归类为 2xx / 3xx / 4xx / 5xx。
+func StatusClass(code int) string {
+	if code < 100 || code > 599 {
+		return "error"
+	}
+	return strconv.Itoa(code/100) + "xx"
+}
@@ -0,0 +1,139 @@
+// Package prommetrics 是 metrics.Recorder 的 Prometheus 实现。
+package prommetrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/dq/kyc-sdk/breaker"
+	"github.com/dq/kyc-sdk/metrics"
+	"github.com/dq/kyc-sdk/model"
+)
+
+// Recorder 导出以下指标（默认 namespace 为 kyc），tenant 标签由 WithTenant 设置，未设置时为空：
+//
+//	kyc_requests_total{tenant,provider,operation,status_class}
+//	kyc_request_duration_seconds{tenant,provider,operation,status_class}
+//	kyc_rate_limited_total{tenant,provider,operation}
+//	kyc_retries_total{tenant,provider,operation}
+//	kyc_webhook_verifications_total{tenant,provider,outcome}
+//	kyc_webhook_events_total{tenant,provider,type}
+//	kyc_review_results_total{tenant,provider,result}
+//	kyc_circuit_breaker_state{tenant,breaker}（0 关闭，1 半开，2 打开）
+type Recorder struct {
+	requests      *prometheus.CounterVec
+	duration      prometheus.ObserverVec
+	rateLimited   *prometheus.CounterVec
+	retries       *prometheus.CounterVec
+	verifications *prometheus.CounterVec
+	events        *prometheus.CounterVec
+	reviews       *prometheus.CounterVec
+	breakers      *prometheus.GaugeVec
+
+	root *Recorder // 未固定 tenant 的指标，WithTenant 基于它派生
+}
+
+type Options struct {
+	Namespace string    // 默认 kyc
+	Buckets   []float64 // 耗时直方图的 bucket（秒），默认 prometheus.DefBuckets
+}
+
+// New 创建 Recorder 并注册到 reg；reg 为 nil 时使用 prometheus.DefaultRegisterer。
+func New(reg prometheus.Registerer, opts Options) (*Recorder, error) {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = "kyc"
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = prometheus.DefBuckets
+	}
+
+	ns := opts.Namespace
+	r := &Recorder{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "requests_total", Help: "KYC provider calls by operation and status class.",
+		}, []string{"tenant", "provider", "operation", "status_class"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns, Name: "request_duration_seconds", Help: "KYC provider call latency.", Buckets: opts.Buckets,
+		}, []string{"tenant", "provider", "operation", "status_class"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "rate_limited_total", Help: "HTTP 429 responses from KYC providers.",
+		}, []string{"tenant", "provider", "operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "retries_total", Help: "Retried HTTP attempts to KYC providers.",
+		}, []string{"tenant", "provider", "operation"}),
+		verifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "webhook_verifications_total", Help: "Webhook verifications by outcome.",
+		}, []string{"tenant", "provider", "outcome"}),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "webhook_events_total", Help: "Verified webhook events by type.",
+		}, []string{"tenant", "provider", "type"}),
+		reviews: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "review_results_total", Help: "Review results delivered by webhook.",
+		}, []string{"tenant", "provider", "result"}),
+		breakers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns, Name: "circuit_breaker_state", Help: "Circuit breaker state: 0 closed, 1 half-open, 2 open.",
+		}, []string{"tenant", "breaker"}),
+	}
+
+	for _, c := range []prometheus.Collector{r.requests, r.duration, r.rateLimited, r.retries, r.verifications, r.events, r.reviews, r.breakers} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	r.root = r
+	return r.WithTenant("").(*Recorder), nil
+}
+
+// WithTenant 返回把 tenant 标签固定为 tenant 的 Recorder，与 r 共享同一组指标。
+func (r *Recorder) WithTenant(tenant string) metrics.Recorder {
+	labels := prometheus.Labels{"tenant": tenant}
+	r = r.root
+	return &Recorder{
+		requests:      r.requests.MustCurryWith(labels),
+		duration:      r.duration.MustCurryWith(labels),
+		rateLimited:   r.rateLimited.MustCurryWith(labels),
+		retries:       r.retries.MustCurryWith(labels),
+		verifications: r.verifications.MustCurryWith(labels),
+		events:        r.events.MustCurryWith(labels),
+		reviews:       r.reviews.MustCurryWith(labels),
+		breakers:      r.breakers.MustCurryWith(labels),
+		root:          r,
+	}
+}
+
+func (r *Recorder) ObserveRequest(provider, operation, statusClass string, latency time.Duration) {
+	r.requests.WithLabelValues(provider, operation, statusClass).Inc()
+	r.duration.WithLabelValues(provider, operation, statusClass).Observe(latency.Seconds())
+}
+
+func (r *Recorder) IncRateLimited(provider, operation string) {
+	r.rateLimited.WithLabelValues(provider, operation).Inc()
+}
+
+func (r *Recorder) IncRetry(provider, operation string) {
+	r.retries.WithLabelValues(provider, operation).Inc()
+}
+
+func (r *Recorder) ObserveWebhook(provider string, outcome metrics.WebhookOutcome) {
+	r.verifications.WithLabelValues(provider, string(outcome)).Inc()
+}
+
+func (r *Recorder) IncWebhookEvent(provider string, eventType model.WebhookEventType) {
+	r.events.WithLabelValues(provider, string(eventType)).Inc()
+}
+
+func (r *Recorder) IncReviewResult(provider string, result model.KycResult) {
+	r.reviews.WithLabelValues(provider, string(result)).Inc()
+}
+
+// CircuitStateChanged 可直接作为 breaker.Settings.OnStateChange，把熔断器状态导出为 gauge。
+// 多租户时使用对应租户的 Recorder（WithTenant 的返回值）注册，gauge 带上 tenant 标签。
+func (r *Recorder) CircuitStateChanged(name string, from, to breaker.State) {
+	r.breakers.WithLabelValues(name).Set(float64(to))
+}
+
+var _ metrics.TenantRecorder = (*Recorder)(nil)
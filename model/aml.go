@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// AMLHitCategory 是 AML 命中所属的名单类别。
+type AMLHitCategory string
+
+const (
+	AMLCategorySanctions    AMLHitCategory = "SANCTIONS"
+	AMLCategoryPEP          AMLHitCategory = "PEP"
+	AMLCategoryAdverseMedia AMLHitCategory = "ADVERSE_MEDIA"
+	AMLCategoryOther        AMLHitCategory = "OTHER"
+)
+
+// AMLMatchStrength 表示命中与 applicant 的匹配程度。
+type AMLMatchStrength string
+
+const (
+	AMLMatchExact  AMLMatchStrength = "EXACT"
+	AMLMatchStrong AMLMatchStrength = "STRONG"
+	AMLMatchMedium AMLMatchStrength = "MEDIUM"
+	AMLMatchWeak   AMLMatchStrength = "WEAK"
+	// AMLMatchUnknown 表示 Provider 未给出或给出了无法识别的匹配程度，应按需人工复核，不能视为弱匹配。
+	AMLMatchUnknown AMLMatchStrength = "UNKNOWN"
+)
+
+// AMLEntityType 表示命中实体的类型。
+type AMLEntityType string
+
+const (
+	AMLEntityPerson       AMLEntityType = "PERSON"
+	AMLEntityOrganization AMLEntityType = "ORGANIZATION"
+	AMLEntityVessel       AMLEntityType = "VESSEL"
+	AMLEntityUnknown      AMLEntityType = "UNKNOWN"
+)
+
+// AMLHit 是一条制裁 / PEP / 负面新闻命中记录。
+type AMLHit struct {
+	Name          string           // 命中实体名称
+	ListName      string           // 名单名称（例如 OFAC SDN、EU Consolidated）
+	Category      AMLHitCategory   // 名单类别
+	MatchStrength AMLMatchStrength // 匹配程度
+	EntityType    AMLEntityType    // 实体类型
+	Countries     []string         // 实体关联国家
+}
+
+// AMLResult 是一次 AML 筛查（含持续监控复查）的结果。
+type AMLResult struct {
+	ApplicantID string
+	CheckID     string
+	Result      KycResult // GREEN：无命中；RED：确认命中；YELLOW：待人工确认
+	CheckedAt   time.Time
+	Hits        []AMLHit
+}
@@ -6,6 +6,7 @@ type ApplicantInfo struct {
 	Status      KycStatus
 	Result      KycResult
 	Provider    string
+	Environment string // sandbox / production，Client 无法确定环境时为空
 }
 
 type KycStatus string
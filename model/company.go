@@ -0,0 +1,59 @@
+package model
+
+// CreateCompanyRequest 是创建企业（KYB）applicant 的请求。
+type CreateCompanyRequest struct {
+	UserID             string // 外部企业唯一标识(建议使用项目名加企业ID)
+	LevelName          string // Sumsub 配置的 KYB level 名称
+	CompanyName        string // 企业名称
+	RegistrationNumber string // 企业注册号
+	Country            string // 注册国家（ISO 3166-1 alpha-3，例如 SGP）
+	LegalAddress       string // 注册地址
+	Email              string // 企业联系邮箱
+	Phone              string // 企业联系电话
+}
+
+// BeneficiaryType 表示受益人在企业中的角色。
+type BeneficiaryType string
+
+const (
+	BeneficiaryUBO            BeneficiaryType = "ubo"
+	BeneficiaryDirector       BeneficiaryType = "director"
+	BeneficiaryShareholder    BeneficiaryType = "shareholder"
+	BeneficiaryRepresentative BeneficiaryType = "representative"
+)
+
+// AddBeneficiaryRequest 把一个已创建的个人 applicant 关联为企业受益人。
+type AddBeneficiaryRequest struct {
+	ApplicantID string            // 受益人自身的个人 applicant ID
+	Types       []BeneficiaryType // 受益人角色，可多选
+	ShareSize   float64           // 持股比例（百分比），非股东可为 0
+}
+
+// Beneficiary 是企业下的一名受益人（UBO / 董事 / 股东等）。
+type Beneficiary struct {
+	ID          string // Provider 侧受益人记录 ID
+	ApplicantID string // 受益人自身的个人 applicant ID，尚未关联时为空
+	Types       []BeneficiaryType
+	ShareSize   float64
+	Status      KycStatus // 受益人个人 applicant 的审核状态，未关联 applicant 时为 UNKNOWN
+	Result      KycResult // 受益人个人 applicant 的审核结论
+}
+
+// CompanyInfo 是企业 applicant 的信息与 KYB 汇总结果。
+//
+// Status / Result 汇总了企业自身与全部受益人的审核结果：
+// - 任一方为 RED 则为 RED
+// - 全部为 GREEN 才为 GREEN
+// - 任一方仍在审核中则 Status 为 PENDING
+type CompanyInfo struct {
+	UserID             string
+	ApplicantID        string
+	CompanyName        string
+	RegistrationNumber string
+	Country            string
+	LegalAddress       string
+	Status             KycStatus
+	Result             KycResult
+	Beneficiaries      []Beneficiary
+	Provider           string
+}
@@ -0,0 +1,15 @@
+package model
+
+// QuestionnaireAnswer 是问卷中一个问题的答案。
+type QuestionnaireAnswer struct {
+	Section string   // 分区 ID（在 Provider 后台配置）
+	Item    string   // 问题 ID（在 Provider 后台配置）
+	Value   string   // 单选 / 文本答案
+	Values  []string // 多选答案
+}
+
+// Questionnaire 是 level 中配置的问卷（例如资金来源问卷）及其答案。
+type Questionnaire struct {
+	ID      string // 问卷 ID（在 Provider 后台配置）
+	Answers []QuestionnaireAnswer
+}
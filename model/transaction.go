@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+// TransactionDirection 是交易方向（相对 applicant 而言）。
+type TransactionDirection string
+
+const (
+	TransactionIn  TransactionDirection = "in"  // 入金：资金流入 applicant
+	TransactionOut TransactionDirection = "out" // 出金：资金流出 applicant
+)
+
+// PaymentMethod 是交易使用的支付方式。
+type PaymentMethod struct {
+	Type      string // 支付方式类型，例如 card、bank_account、crypto_wallet
+	AccountID string // 卡号掩码 / 银行账号 / 钱包地址等
+	Issuer    string // 发卡行 / 开户行 / 钱包服务商
+}
+
+// Counterparty 是交易对手方。
+type Counterparty struct {
+	ExternalUserID string // 对手方在业务侧的标识（可为空）
+	FullName       string // 对手方名称
+	Type           string // individual 或 company
+	Country        string // 对手方所在国家（ISO 3166-1 alpha-3）
+	PaymentMethod  PaymentMethod
+}
+
+// DeviceInfo 是发起交易时 applicant 的设备信息。
+type DeviceInfo struct {
+	IPAddress   string
+	Fingerprint string
+	UserAgent   string
+}
+
+// Transaction 是提交给交易监控（KYT）的一笔交易。
+type Transaction struct {
+	ID            string               // 业务侧交易唯一 ID
+	Amount        float64              // 交易金额
+	Currency      string               // 币种（ISO 4217 或加密货币代码）
+	Direction     TransactionDirection // 交易方向
+	OccurredAt    time.Time            // 交易发生时间，零值表示当前时间
+	Counterparty  Counterparty
+	PaymentMethod PaymentMethod // applicant 一侧使用的支付方式
+	Device        DeviceInfo
+}
+
+// TransactionReview 是交易监控的审核结果。
+type TransactionReview struct {
+	ID            string // Provider 侧交易 ID
+	TransactionID string // 业务侧交易 ID
+	ApplicantID   string
+	Status        KycStatus
+	Result        KycResult
+	Score         float64 // 风险评分（取值范围以 Provider 为准）
+}
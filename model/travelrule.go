@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// VASP 是虚拟资产服务商（交易所、托管钱包等）。
+type VASP struct {
+	ID   string // VASP 标识（DID / LEI / Provider 侧 ID）
+	Name string // VASP 名称
+}
+
+// TravelRuleParty 是 Travel Rule 中的发起方或受益方。
+type TravelRuleParty struct {
+	ExternalUserID string // 业务侧用户标识（仅本方可填）
+	FullName       string // 姓名或企业名称
+	Type           string // individual 或 company
+	Country        string // 所在国家（ISO 3166-1 alpha-3）
+	WalletAddress  string // 钱包地址
+	VASP           VASP   // 托管该钱包的 VASP，自托管钱包留空
+}
+
+// TravelRuleTransfer 是一笔需要交换 Travel Rule 数据的加密货币转账。
+//
+// Direction 为 out（提币）时 applicant 是 Originator；为 in（充币）时 applicant 是 Beneficiary。
+type TravelRuleTransfer struct {
+	ID          string               // 业务侧转账唯一 ID
+	Direction   TransactionDirection // 转账方向
+	Amount      float64              // 转账数量
+	Asset       string               // 币种，例如 BTC、USDT
+	Network     string               // 链 / 网络，例如 ETH、TRX
+	TxHash      string               // 链上交易哈希（广播后可填）
+	OccurredAt  time.Time            // 转账时间，零值表示当前时间
+	Originator  TravelRuleParty
+	Beneficiary TravelRuleParty
+}
+
+// TravelRuleStatus 是 Travel Rule 数据交换的状态。
+type TravelRuleStatus string
+
+const (
+	TravelRulePending              TravelRuleStatus = "PENDING"               // 已提交，等待处理
+	TravelRuleAwaitingCounterparty TravelRuleStatus = "AWAITING_COUNTERPARTY" // 等待对方 VASP 确认
+	TravelRuleConfirmed            TravelRuleStatus = "CONFIRMED"             // 对方已确认
+	TravelRuleRejected             TravelRuleStatus = "REJECTED"              // 对方已拒绝
+	TravelRuleUnknown              TravelRuleStatus = "UNKNOWN"
+)
+
+// TravelRuleInfo 是一笔 Travel Rule 转账的处理状态。
+type TravelRuleInfo struct {
+	ID          string // Provider 侧转账 ID
+	TransferID  string // 业务侧转账 ID
+	ApplicantID string
+	Status      TravelRuleStatus
+	Result      KycResult // 交易监控对该转账的审核结论
+}
@@ -1,5 +1,7 @@
 package model
 
+import "log/slog"
+
 type GenerateLinkRequest struct {
 	UserID     string // 外部用户唯一标识(建议使用项目名加用户ID)
 	LevelName  string // Sumsub 配置的 level 名称
@@ -8,14 +10,40 @@ type GenerateLinkRequest struct {
 	Phone      string // 用户手机号
 	SuccessURL string // 认证成功跳转地址
 	RejectURL  string // 认证拒绝跳转地址
+	Country    string // 用户所在国家（ISO 3166-1 alpha-3），用于多 Provider 路由
+	Segment    string // 用户分群（例如 vip、retail），用于多 Provider 路由
+	// ApplicantID 是该用户已有的 applicant ID（目前 Onfido 使用）：非空时直接为其创建认证流程，不再新建 applicant。
+	ApplicantID string
+}
+
+// LogValue 实现 slog.LogValuer，记录日志时隐藏邮箱与手机号。
+func (r GenerateLinkRequest) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("user_id", r.UserID),
+		slog.String("level_name", r.LevelName),
+		slog.Int("ttl", int(r.TTL)),
+		slog.String("email", redacted(r.Email)),
+		slog.String("phone", redacted(r.Phone)),
+		slog.String("country", r.Country),
+		slog.String("segment", r.Segment),
+		slog.String("applicant_id", r.ApplicantID),
+	)
+}
+
+func redacted(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "REDACTED"
 }
 
 // WebhookPayload 是 Sumsub Webhook 回调的核心结构。
 //
-// 常见 Type（事件类型）示例：
+// 常见 Type（事件类型）示例，完整列表见 webhook.go 中的 Event* 常量：
 // - applicantCreated：创建 applicant
 // - applicantPending：进入审核队列/等待审核
 // - applicantPersonalInfoChanged：个人信息变更
+// - applicantAmlCaseChanged：AML 案件变化（持续监控命中）
 type WebhookPayload struct {
 	// Type 表示本次回调的事件类型。
 	Type string `json:"type"`
@@ -25,4 +53,12 @@ type WebhookPayload struct {
 	ExternalUserID string `json:"externalUserId"`
 	// ReviewStatus 是审核流程状态
 	ReviewStatus string `json:"reviewStatus"`
+	// ReviewResult 是审核结论（仅 applicantReviewed 等事件携带，其余为 NONE）。
+	ReviewResult KycResult `json:"reviewResult"`
+	// RejectLabels 是拒绝原因标签（例如 FORGERY、SANCTIONS）。
+	RejectLabels []string `json:"rejectLabels,omitempty"`
+	// TransactionID 是交易监控事件对应的业务侧交易 ID（仅 KYT 事件携带）。
+	TransactionID string `json:"transactionId,omitempty"`
+	// Environment 是回调来源环境（sandbox / production），无法确定时为空。
+	Environment string `json:"environment,omitempty"`
 }
@@ -0,0 +1,27 @@
+package model
+
+// WebhookEventType 是 Webhook 回调的事件类型。
+type WebhookEventType string
+
+const (
+	EventApplicantCreated             WebhookEventType = "applicantCreated"
+	EventApplicantPending             WebhookEventType = "applicantPending"
+	EventApplicantReviewed            WebhookEventType = "applicantReviewed"
+	EventApplicantOnHold              WebhookEventType = "applicantOnHold"
+	EventApplicantPersonalInfoChanged WebhookEventType = "applicantPersonalInfoChanged"
+
+	// EventApplicantAMLCaseChanged 表示 AML 案件状态发生变化（包括持续监控发现新的命中）。
+	EventApplicantAMLCaseChanged WebhookEventType = "applicantAmlCaseChanged"
+	// EventApplicantAMLOngoingMonitoring 表示持续监控（ongoing monitoring）复查完成。
+	EventApplicantAMLOngoingMonitoring WebhookEventType = "applicantAmlOngoingMonitoring"
+
+	// EventApplicantKytTxnApproved 表示交易监控已放行一笔交易。
+	EventApplicantKytTxnApproved WebhookEventType = "applicantKytTxnApproved"
+	// EventApplicantKytOnHold 表示一笔交易被交易监控挂起，需要人工处理。
+	EventApplicantKytOnHold WebhookEventType = "applicantKytOnHold"
+)
+
+// EventType 返回 Type 对应的 WebhookEventType，便于与 Event* 常量比较。
+func (p WebhookPayload) EventType() WebhookEventType {
+	return WebhookEventType(p.Type)
+}
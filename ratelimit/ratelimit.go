@@ -0,0 +1,188 @@
+// Package ratelimit 提供按接口分组的客户端令牌桶限流，避免批量任务把 Provider 的配额打满。
+//
+// 一个 Limiter 可以被多个 goroutine、多个 Client 共享（通过 config.Config.RateLimiter），
+// 共享时它们共用同一份配额。收到 429 时对应分组会暂停到 Retry-After 之后，
+// 并把速率减半，之后每次成功的请求逐步恢复到配置的速率。
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Group 是共享同一份配额的一组接口。
+type Group string
+
+const (
+	GroupRead  Group = "read"  // applicant 等资源的查询（GET）
+	GroupWrite Group = "write" // 创建 / 修改类请求
+	GroupLink  Group = "link"  // 生成认证链接（GenerateLink）
+)
+
+// Limit 是一个分组的配额。PerSecond <= 0 表示该分组不限流。
+type Limit struct {
+	PerSecond float64
+	Burst     int // 允许的突发请求数，默认 max(1, PerSecond)
+}
+
+// Limits 是各分组的配额，未配置的分组不限流。
+type Limits map[Group]Limit
+
+// DefaultLimits 是偏保守的默认配额，应按账户在 Provider 侧的实际配额调整。
+func DefaultLimits() Limits {
+	return Limits{
+		GroupRead:  {PerSecond: 20, Burst: 20},
+		GroupWrite: {PerSecond: 5, Burst: 5},
+		GroupLink:  {PerSecond: 5, Burst: 5},
+	}
+}
+
+const (
+	// defaultBackoff 是 429 未携带 Retry-After 时的暂停时间。
+	defaultBackoff = time.Second
+	// minRateFactor 是连续 429 后速率可降到的下限（相对配置值）。
+	minRateFactor = 0.1
+	// recoverStep 是每次成功请求恢复的速率（相对配置值）。
+	recoverStep = 0.05
+)
+
+type Limiter struct {
+	now     func() time.Time
+	buckets map[Group]*bucket
+}
+
+// New 按 limits 创建 Limiter。
+func New(limits Limits) *Limiter {
+	l := &Limiter{now: time.Now, buckets: make(map[Group]*bucket, len(limits))}
+	now := l.now()
+	for g, lim := range limits {
+		if lim.PerSecond <= 0 {
+			continue
+		}
+		burst := lim.Burst
+		if burst <= 0 {
+			burst = int(math.Max(1, lim.PerSecond))
+		}
+		l.buckets[g] = &bucket{
+			base:   lim.PerSecond,
+			rate:   lim.PerSecond,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			last:   now,
+		}
+	}
+	return l
+}
+
+// Wait 阻塞到 g 分组有可用配额，ctx 结束时返回 ctx.Err() 并归还预占的配额。
+func (l *Limiter) Wait(ctx context.Context, g Group) error {
+	if l == nil {
+		return nil
+	}
+	b, ok := l.buckets[g]
+	if !ok {
+		return nil
+	}
+
+	wait := b.reserve(l.now())
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel(l.now())
+		return ctx.Err()
+	}
+}
+
+// Throttled 在 g 分组收到 429 时调用：暂停到 retryAfter 之后（<= 0 时为 1 秒），并把速率减半。
+func (l *Limiter) Throttled(g Group, retryAfter time.Duration) {
+	if l == nil {
+		return
+	}
+	if b, ok := l.buckets[g]; ok {
+		if retryAfter <= 0 {
+			retryAfter = defaultBackoff
+		}
+		b.throttle(l.now(), retryAfter)
+	}
+}
+
+// Succeeded 在 g 分组的请求成功时调用，逐步恢复被 Throttled 降低的速率。
+func (l *Limiter) Succeeded(g Group) {
+	if l == nil {
+		return
+	}
+	if b, ok := l.buckets[g]; ok {
+		b.recover(l.now())
+	}
+}
+
+// bucket 是允许预占的令牌桶：tokens 可以为负，表示已经排队等待的请求。
+type bucket struct {
+	mu     sync.Mutex
+	base   float64 // 配置的速率
+	rate   float64 // 当前速率
+	burst  float64
+	tokens float64
+	last   time.Time // tokens 对应的时间点，暂停期间在未来
+}
+
+func (b *bucket) advance(now time.Time) {
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// reserve 预占一个令牌，返回需要等待的时间。
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+	b.tokens--
+	ready := b.last
+	if b.tokens < 0 {
+		ready = ready.Add(time.Duration(-b.tokens / b.rate * float64(time.Second)))
+	}
+	return ready.Sub(now)
+}
+
+func (b *bucket) cancel(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+func (b *bucket) throttle(now time.Time, retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+	b.rate = math.Max(b.base*minRateFactor, b.rate/2)
+	// 已排队的请求保留各自的位置，暂停结束前不再补充令牌。
+	b.tokens = math.Min(b.tokens, 0)
+	if until := now.Add(retryAfter); until.After(b.last) {
+		b.last = until
+	}
+}
+
+func (b *bucket) recover(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate < b.base {
+		b.advance(now)
+		b.rate = math.Min(b.base, b.rate+b.base*recoverStep)
+	}
+}
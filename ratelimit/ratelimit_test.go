@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func newTestLimiter(limits Limits) (*Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	l := New(limits)
+	l.now = clock.now
+	for _, b := range l.buckets {
+		b.last = clock.t
+	}
+	return l, clock
+}
+
+func TestBucket_BurstThenRate(t *testing.T) {
+	l, clock := newTestLimiter(Limits{GroupWrite: {PerSecond: 2, Burst: 2}})
+	b := l.buckets[GroupWrite]
+
+	for i := 0; i < 2; i++ {
+		if wait := b.reserve(clock.now()); wait != 0 {
+			t.Fatalf("burst request %d waited %v", i, wait)
+		}
+	}
+	if wait := b.reserve(clock.now()); wait != 500*time.Millisecond {
+		t.Fatalf("expected 500ms, got %v", wait)
+	}
+	if wait := b.reserve(clock.now()); wait != time.Second {
+		t.Fatalf("expected queued request to wait 1s, got %v", wait)
+	}
+
+	clock.add(time.Second)
+	if wait := b.reserve(clock.now()); wait != 500*time.Millisecond {
+		t.Fatalf("expected 500ms after refill, got %v", wait)
+	}
+}
+
+func TestLimiter_UnconfiguredGroupUnlimited(t *testing.T) {
+	l, _ := newTestLimiter(Limits{GroupWrite: {PerSecond: 1}})
+	for i := 0; i < 100; i++ {
+		if err := l.Wait(context.Background(), GroupRead); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	var nilLimiter *Limiter
+	if err := nilLimiter.Wait(context.Background(), GroupRead); err != nil {
+		t.Fatalf("nil limiter Wait: %v", err)
+	}
+}
+
+func TestLimiter_WaitHonorsContext(t *testing.T) {
+	l, clock := newTestLimiter(Limits{GroupRead: {PerSecond: 1, Burst: 1}})
+	if err := l.Wait(context.Background(), GroupRead); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, GroupRead); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got: %v", err)
+	}
+
+	// 取消的请求归还了预占的令牌，下一个请求只需等待第一个令牌补充。
+	if wait := l.buckets[GroupRead].reserve(clock.now()); wait != time.Second {
+		t.Fatalf("expected 1s, got %v", wait)
+	}
+}
+
+func TestLimiter_ThrottledPausesAndRecovers(t *testing.T) {
+	l, clock := newTestLimiter(Limits{GroupLink: {PerSecond: 10, Burst: 10}})
+	b := l.buckets[GroupLink]
+
+	l.Throttled(GroupLink, 3*time.Second)
+	if b.rate != 5 {
+		t.Fatalf("expected rate halved to 5, got %v", b.rate)
+	}
+	if wait := b.reserve(clock.now()); wait != 3*time.Second+200*time.Millisecond {
+		t.Fatalf("expected to wait for Retry-After plus one token, got %v", wait)
+	}
+
+	for i := 0; i < 10; i++ {
+		l.Throttled(GroupLink, 0)
+	}
+	if b.rate != 1 {
+		t.Fatalf("expected rate floor 1, got %v", b.rate)
+	}
+
+	for i := 0; i < 100; i++ {
+		l.Succeeded(GroupLink)
+	}
+	if b.rate != 10 {
+		t.Fatalf("expected rate recovered to 10, got %v", b.rate)
+	}
+}